@@ -0,0 +1,268 @@
+package backend
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rclone/rclone/fs"
+)
+
+// BandwidthLimit caps how fast transfers upload and download. Rates use rclone's size syntax, so
+// "5M" is 5 MiB/s and "512k" is 512 KiB/s; an empty rate or "off" is unlimited.
+type BandwidthLimit struct {
+	Upload   string                   `json:"upload"`
+	Download string                   `json:"download"`
+	Schedule []BandwidthScheduleEntry `json:"schedule"` // Replaces Upload and Download when not empty
+}
+
+// BandwidthScheduleEntry sets the rates from its start time until the next entry starts, like an
+// rclone bwlimit timetable. "5M during office hours, unlimited at night" is an entry starting at
+// 09:00 with 5M rates followed by one starting at 18:00 with empty rates.
+type BandwidthScheduleEntry struct {
+	Days     []string `json:"days"`  // "mon" to "sun"; empty for every day
+	Start    string   `json:"start"` // "HH:MM" in local time
+	Upload   string   `json:"upload"`
+	Download string   `json:"download"`
+}
+
+var weekdayNames = []string{"sun", "mon", "tue", "wed", "thu", "fri", "sat"}
+
+// bandwidthState tracks the rate last applied to rclone, so the scheduler only calls
+// core/bwlimit when the rate actually changes.
+type bandwidthState struct {
+	rate      string
+	mu        sync.Mutex
+	scheduler sync.Once
+}
+
+// validateBandwidthRate checks that the rate is empty, "off", or an rclone size.
+func validateBandwidthRate(rate string) error {
+	if rate == "" {
+		return nil
+	}
+	var size fs.SizeSuffix
+	if err := size.Set(rate); err != nil {
+		return fmt.Errorf("invalid rate '%s': %v", rate, err)
+	}
+	return nil
+}
+
+// parseScheduleStart parses an "HH:MM" start time into minutes after midnight.
+func parseScheduleStart(start string) (int, error) {
+	parsed, err := time.Parse("15:04", start)
+	if err != nil {
+		return 0, fmt.Errorf("invalid start time '%s', expected HH:MM", start)
+	}
+	return parsed.Hour()*60 + parsed.Minute(), nil
+}
+
+// scheduleDays returns the weekdays the entry applies to.
+func (entry *BandwidthScheduleEntry) scheduleDays() ([]time.Weekday, error) {
+	if len(entry.Days) == 0 {
+		return []time.Weekday{time.Sunday, time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday, time.Saturday}, nil
+	}
+	days := make([]time.Weekday, 0, len(entry.Days))
+	for _, day := range entry.Days {
+		found := false
+		for i, name := range weekdayNames {
+			if strings.HasPrefix(strings.ToLower(day), name) {
+				days = append(days, time.Weekday(i))
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("invalid day '%s', expected mon to sun", day)
+		}
+	}
+	return days, nil
+}
+
+// validate checks every rate, start time, and day of the limit.
+func (bl *BandwidthLimit) validate() error {
+	if err := validateBandwidthRate(bl.Upload); err != nil {
+		return fmt.Errorf("upload limit: %v", err)
+	}
+	if err := validateBandwidthRate(bl.Download); err != nil {
+		return fmt.Errorf("download limit: %v", err)
+	}
+	for i, entry := range bl.Schedule {
+		if _, err := parseScheduleStart(entry.Start); err != nil {
+			return fmt.Errorf("schedule entry %d: %v", i+1, err)
+		}
+		if _, err := entry.scheduleDays(); err != nil {
+			return fmt.Errorf("schedule entry %d: %v", i+1, err)
+		}
+		if err := validateBandwidthRate(entry.Upload); err != nil {
+			return fmt.Errorf("schedule entry %d upload limit: %v", i+1, err)
+		}
+		if err := validateBandwidthRate(entry.Download); err != nil {
+			return fmt.Errorf("schedule entry %d download limit: %v", i+1, err)
+		}
+	}
+	return nil
+}
+
+// clone returns a copy of the limit. A nil limit stays nil.
+func (bl *BandwidthLimit) clone() *BandwidthLimit {
+	if bl == nil {
+		return nil
+	}
+	clone := *bl
+	if bl.Schedule != nil {
+		clone.Schedule = make([]BandwidthScheduleEntry, len(bl.Schedule))
+		for i, entry := range bl.Schedule {
+			clone.Schedule[i] = entry
+			clone.Schedule[i].Days = cloneStrings(entry.Days)
+		}
+	}
+	return &clone
+}
+
+// rateAt returns the limit in effect at the given time, in the "upload:download" form that
+// core/bwlimit takes. Without a schedule entry at or before the time this week, the last entry
+// of the week is still in effect.
+func (bl *BandwidthLimit) rateAt(now time.Time) string {
+	if len(bl.Schedule) == 0 {
+		return bandwidthRate(bl.Upload, bl.Download)
+	}
+
+	const minutesPerWeek = 7 * 24 * 60
+	nowMinute := int(now.Weekday())*24*60 + now.Hour()*60 + now.Minute()
+	current, currentMinute := -1, -1
+	latest, latestMinute := -1, -1
+	for i, entry := range bl.Schedule {
+		start, startErr := parseScheduleStart(entry.Start)
+		days, daysErr := entry.scheduleDays()
+		if startErr != nil || daysErr != nil {
+			continue
+		}
+		for _, day := range days {
+			minute := (int(day)*24*60 + start) % minutesPerWeek
+			if minute <= nowMinute && minute >= currentMinute {
+				current, currentMinute = i, minute
+			}
+			if minute >= latestMinute {
+				latest, latestMinute = i, minute
+			}
+		}
+	}
+	if current < 0 {
+		current = latest
+	}
+	if current < 0 {
+		return bandwidthRate("", "")
+	}
+	return bandwidthRate(bl.Schedule[current].Upload, bl.Schedule[current].Download)
+}
+
+// bandwidthRate joins an upload and a download rate into an rclone bandwidth pair.
+func bandwidthRate(upload string, download string) string {
+	if upload == "" {
+		upload = "off"
+	}
+	if download == "" {
+		download = "off"
+	}
+	return upload + ":" + download
+}
+
+// effectiveBandwidthLimit returns the project's own limit if it has one, or the global limit.
+func (gc *GlobalConfig) effectiveBandwidthLimit(project string) BandwidthLimit {
+	if remote, exists := gc.Remotes[project]; exists && remote.BandwidthLimit != nil {
+		return *remote.BandwidthLimit
+	}
+	return gc.BandwidthLimit
+}
+
+// applyBandwidthLimit sets rclone's bandwidth limit to the one in effect right now for the
+// selected project. rclone has a single limiter shared by every transfer, so the change also
+// applies to jobs that are already running.
+func (cm *ConfigManager) applyBandwidthLimit() error {
+	globalConfig := cm.GetGlobalConfig()
+	limit := globalConfig.effectiveBandwidthLimit(globalConfig.SelectedProject)
+	rate := limit.rateAt(time.Now())
+
+	cm.bandwidth.mu.Lock()
+	defer cm.bandwidth.mu.Unlock()
+	if rate == cm.bandwidth.rate {
+		return nil
+	}
+	if err := RcloneSetBandwidthLimit(rate); err != nil {
+		return err
+	}
+	cm.bandwidth.rate = rate
+	fmt.Printf("Bandwidth limit set to %s (upload:download)\n", rate)
+	return nil
+}
+
+// startBandwidthScheduler applies the bandwidth limit now and then at the start of every minute,
+// so schedule entries take effect on time. It only starts once.
+func (cm *ConfigManager) startBandwidthScheduler() {
+	cm.bandwidth.scheduler.Do(func() {
+		go func() {
+			for {
+				if err := cm.applyBandwidthLimit(); err != nil {
+					fmt.Printf("Warning: Failed to apply bandwidth limit: %v\n", err)
+				}
+				now := time.Now()
+				time.Sleep(now.Truncate(time.Minute).Add(time.Minute).Sub(now))
+			}
+		}()
+	})
+}
+
+// GetBandwidthRate returns the bandwidth limit currently applied to transfers, as an
+// "upload:download" pair where "off" is unlimited.
+func (cs *ConfigService) GetBandwidthRate() string {
+	cs.configManager.bandwidth.mu.Lock()
+	defer cs.configManager.bandwidth.mu.Unlock()
+	return cs.configManager.bandwidth.rate
+}
+
+// SetBandwidthLimit sets the bandwidth limit of every project without a limit of its own. The
+// new limit applies to running transfers right away.
+func (cs *ConfigService) SetBandwidthLimit(limit BandwidthLimit) (GlobalConfigView, error) {
+	view, err := cs.updateGlobalConfig(func(globalConfig *GlobalConfig, credentialStore CredentialStore) error {
+		if err := limit.validate(); err != nil {
+			return err
+		}
+		globalConfig.BandwidthLimit = *limit.clone()
+		return nil
+	})
+	if err != nil {
+		return view, err
+	}
+	if applyErr := cs.configManager.applyBandwidthLimit(); applyErr != nil {
+		return view, fmt.Errorf("the limit was saved but could not be applied: %v", applyErr)
+	}
+	return view, nil
+}
+
+// SetProjectBandwidthLimit sets the bandwidth limit of a single project, replacing the global
+// limit for it. A nil limit makes the project use the global limit again.
+func (cs *ConfigService) SetProjectBandwidthLimit(projectName string, limit *BandwidthLimit) (GlobalConfigView, error) {
+	view, err := cs.updateGlobalConfig(func(globalConfig *GlobalConfig, credentialStore CredentialStore) error {
+		remote, exists := globalConfig.Remotes[projectName]
+		if !exists {
+			return fmt.Errorf("project '%s' does not exist", projectName)
+		}
+		if limit != nil {
+			if err := limit.validate(); err != nil {
+				return err
+			}
+		}
+		remote.BandwidthLimit = limit.clone()
+		globalConfig.Remotes[projectName] = remote
+		return nil
+	})
+	if err != nil {
+		return view, err
+	}
+	if applyErr := cs.configManager.applyBandwidthLimit(); applyErr != nil {
+		return view, fmt.Errorf("the limit was saved but could not be applied: %v", applyErr)
+	}
+	return view, nil
+}
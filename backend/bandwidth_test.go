@@ -0,0 +1,54 @@
+package backend
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBandwidthLimitRateAt(t *testing.T) {
+	officeHours := []BandwidthScheduleEntry{
+		{Days: []string{"mon", "tue", "wed", "thu", "fri"}, Start: "09:00", Upload: "5M", Download: "10M"},
+		{Days: []string{"mon", "tue", "wed", "thu", "fri"}, Start: "18:00"},
+	}
+	// 2026-10-19 is a Monday
+	at := func(day int, hour int, minute int) time.Time {
+		return time.Date(2026, 10, 19+day, hour, minute, 0, 0, time.Local)
+	}
+
+	tests := []struct {
+		name  string
+		limit BandwidthLimit
+		now   time.Time
+		want  string
+	}{
+		{"unlimited", BandwidthLimit{}, at(0, 12, 0), "off:off"},
+		{"fixed rates", BandwidthLimit{Upload: "2M", Download: "8M"}, at(0, 12, 0), "2M:8M"},
+		{"upload only", BandwidthLimit{Upload: "512k"}, at(0, 12, 0), "512k:off"},
+		{"schedule replaces fixed rates", BandwidthLimit{Upload: "1M", Schedule: officeHours}, at(0, 12, 0), "5M:10M"},
+		{"at the start of an entry", BandwidthLimit{Schedule: officeHours}, at(1, 9, 0), "5M:10M"},
+		{"just before an entry", BandwidthLimit{Schedule: officeHours}, at(1, 8, 59), "off:off"},
+		{"evening", BandwidthLimit{Schedule: officeHours}, at(2, 20, 0), "off:off"},
+		{"weekend keeps friday evening", BandwidthLimit{Schedule: officeHours}, at(5, 12, 0), "off:off"},
+		{"monday morning wraps to the last entry", BandwidthLimit{Schedule: []BandwidthScheduleEntry{
+			{Days: []string{"sat"}, Start: "00:00", Upload: "1M"},
+			{Days: []string{"mon"}, Start: "09:00", Upload: "5M"},
+		}}, at(0, 8, 0), "1M:off"},
+		{"every day", BandwidthLimit{Schedule: []BandwidthScheduleEntry{
+			{Start: "01:00", Upload: "20M"},
+			{Start: "07:00", Upload: "2M"},
+		}}, at(6, 3, 30), "20M:off"},
+		{"invalid entries are skipped", BandwidthLimit{Schedule: []BandwidthScheduleEntry{
+			{Start: "25:00", Upload: "9M"},
+			{Days: []string{"someday"}, Start: "08:00", Upload: "9M"},
+			{Start: "08:00", Upload: "3M"},
+		}}, at(3, 10, 0), "3M:off"},
+		{"only invalid entries", BandwidthLimit{Schedule: []BandwidthScheduleEntry{{Start: "noon", Upload: "9M"}}}, at(3, 10, 0), "off:off"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.limit.rateAt(tt.now); got != tt.want {
+				t.Errorf("rateAt(%s) = %q, want %q", tt.now.Format("Mon 15:04"), got, tt.want)
+			}
+		})
+	}
+}
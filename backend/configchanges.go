@@ -0,0 +1,107 @@
+package backend
+
+import "fmt"
+
+// Operations a ConfigChange can perform. Each mirrors the FolderService method of the same name.
+const (
+	ChangeRegisterFolder   = "register_folder"   // FolderKey, Folder
+	ChangeEditFolder       = "edit_folder"       // FolderKey, NewFolderKey, Folder
+	ChangeDeregisterFolder = "deregister_folder" // FolderKey
+	ChangeCreateGroup      = "create_group"      // GroupKey, Group, Index
+	ChangeUpdateGroup      = "update_group"      // GroupKey, Group, Index
+	ChangeDeleteGroup      = "delete_group"      // GroupKey, Mode, TargetGroup
+	ChangeMergeGroups      = "merge_groups"      // GroupKey (source), TargetGroup (destination)
+	ChangeRenameGroup      = "rename_group"      // GroupKey, NewGroupKey, Name
+	ChangeMoveGroup        = "move_group"        // GroupKey, TargetGroup (new parent), Index
+	ChangeReorderGroups    = "reorder_groups"    // GroupKey (parent), OrderedKeys
+	ChangeReorderFolders   = "reorder_folders"   // GroupKey, OrderedKeys
+	ChangeSaveSelection    = "save_selection"    // SelectionKey, Selection
+	ChangeDeleteSelection  = "delete_selection"  // SelectionKey
+)
+
+// ConfigChange is a single project configuration edit in a batch passed to ApplyConfigChanges.
+// Only the fields used by its Op are read.
+type ConfigChange struct {
+	Op           string          `json:"op"`
+	FolderKey    string          `json:"folder_key"`
+	NewFolderKey string          `json:"new_folder_key"`
+	Folder       FolderConfig    `json:"folder"`
+	GroupKey     string          `json:"group_key"`
+	NewGroupKey  string          `json:"new_group_key"`
+	Name         string          `json:"name"`
+	Group        GroupConfig     `json:"group"`
+	TargetGroup  string          `json:"target_group"`
+	Mode         string          `json:"mode"`
+	Index        int             `json:"index"`
+	OrderedKeys  []string        `json:"ordered_keys"`
+	SelectionKey string          `json:"selection_key"`
+	Selection    SelectionConfig `json:"selection"`
+}
+
+// ApplyConfigChanges applies a batch of changes as one transaction. The changes are applied in
+// order to a copy of the project configuration, each seeing the result of the ones before it. If
+// every change succeeds, the copy is saved and synced to the remote once; if any change fails,
+// nothing is saved and the error names the failing change.
+func (fs *FolderService) ApplyConfigChanges(changes []ConfigChange) (ProjectConfig, error) {
+	updatedConfig, err := fs.updateProjectConfig(func(projectConfig *ProjectConfig, projectRoot string) error {
+		if len(changes) == 0 {
+			return fmt.Errorf("no changes to apply")
+		}
+		for i, change := range changes {
+			if err := applyConfigChange(projectConfig, projectRoot, change); err != nil {
+				return fmt.Errorf("change %d of %d (%s) failed, no changes were applied: %v", i+1, len(changes), change.Op, err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return updatedConfig, err
+	}
+
+	// Renamed folders keep their status history, in the order they were renamed
+	selectedProject := fs.configManager.GetSelectedProject()
+	for _, change := range changes {
+		if change.Op == ChangeEditFolder && change.NewFolderKey != "" {
+			fs.configManager.folderState.rename(selectedProject, change.FolderKey, change.NewFolderKey)
+		}
+	}
+	return updatedConfig, nil
+}
+
+// applyConfigChange applies a single change to the project config.
+func applyConfigChange(projectConfig *ProjectConfig, projectRoot string, change ConfigChange) error {
+	switch change.Op {
+	case ChangeRegisterFolder:
+		return applyRegisterFolder(projectConfig, projectRoot, change.FolderKey, change.Folder)
+	case ChangeEditFolder:
+		newFolderKey := change.NewFolderKey
+		if newFolderKey == "" {
+			newFolderKey = change.FolderKey
+		}
+		return applyEditFolder(projectConfig, projectRoot, change.FolderKey, newFolderKey, change.Folder)
+	case ChangeDeregisterFolder:
+		return applyDeregisterFolder(projectConfig, change.FolderKey)
+	case ChangeCreateGroup:
+		return applyCreateGroup(projectConfig, change.GroupKey, change.Group, change.Index)
+	case ChangeUpdateGroup:
+		return applyUpdateGroup(projectConfig, change.GroupKey, change.Group, change.Index)
+	case ChangeDeleteGroup:
+		return applyDeleteGroup(projectConfig, change.GroupKey, change.Mode, change.TargetGroup)
+	case ChangeMergeGroups:
+		return applyMergeGroups(projectConfig, change.GroupKey, change.TargetGroup)
+	case ChangeRenameGroup:
+		return applyRenameGroup(projectConfig, change.GroupKey, change.NewGroupKey, change.Name)
+	case ChangeMoveGroup:
+		return applyMoveGroup(projectConfig, change.GroupKey, change.TargetGroup, change.Index)
+	case ChangeReorderGroups:
+		return applyReorderGroups(projectConfig, change.GroupKey, change.OrderedKeys)
+	case ChangeReorderFolders:
+		return applyReorderFolders(projectConfig, change.GroupKey, change.OrderedKeys)
+	case ChangeSaveSelection:
+		return applySaveSelection(projectConfig, change.SelectionKey, change.Selection)
+	case ChangeDeleteSelection:
+		return applyDeleteSelection(projectConfig, change.SelectionKey)
+	default:
+		return fmt.Errorf("unknown change operation '%s'", change.Op)
+	}
+}
@@ -0,0 +1,254 @@
+package backend
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// newChangeTestConfig returns a small project: two top-level groups, a nested group, two folders
+// in "assets", and a selection of the "assets" group.
+func newChangeTestConfig() *ProjectConfig {
+	return &ProjectConfig{
+		Folders: map[string]FolderConfig{
+			"textures": {LocalPath: "assets/textures", RemotePath: "assets/textures", Group: "assets", SortOrder: 0},
+			"models":   {LocalPath: "assets/models", RemotePath: "assets/models", Group: "assets", SortOrder: 1},
+		},
+		Groups: map[string]GroupConfig{
+			"assets": {Name: "Assets", SortOrder: 0},
+			"shots":  {Name: "Shots", SortOrder: 1},
+			"seq01":  {Name: "seq01", ParentGroup: "shots", SortOrder: 0},
+		},
+		Selections: map[string]SelectionConfig{
+			"lookdev": {Name: "Lookdev", Groups: []string{"assets"}, Folders: []string{"textures"}},
+		},
+	}
+}
+
+func TestApplyConfigChange(t *testing.T) {
+	projectRoot := t.TempDir()
+	for _, dir := range []string{"assets/textures", "assets/models", "assets/rigs", "shots/seq01"} {
+		if err := os.MkdirAll(filepath.Join(projectRoot, filepath.FromSlash(dir)), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		name    string
+		change  ConfigChange
+		wantErr bool
+		check   func(t *testing.T, projectConfig *ProjectConfig)
+	}{
+		{
+			name:   "register folder",
+			change: ConfigChange{Op: ChangeRegisterFolder, FolderKey: "rigs", Folder: FolderConfig{LocalPath: "assets/rigs", Group: "assets"}},
+			check: func(t *testing.T, projectConfig *ProjectConfig) {
+				if got := sortedGroupFolders(projectConfig, "assets"); !reflect.DeepEqual(got, []string{"textures", "models", "rigs"}) {
+					t.Errorf("folders in assets = %v, want rigs last", got)
+				}
+				if got := projectConfig.Folders["rigs"].RemotePath; got != "assets/rigs" {
+					t.Errorf("remote path = %q, want the local path", got)
+				}
+			},
+		},
+		{
+			name:    "register overlapping folder",
+			change:  ConfigChange{Op: ChangeRegisterFolder, FolderKey: "all", Folder: FolderConfig{LocalPath: "assets", Group: "assets"}},
+			wantErr: true,
+		},
+		{
+			name:   "edit folder with a new key",
+			change: ConfigChange{Op: ChangeEditFolder, FolderKey: "models", NewFolderKey: "meshes", Folder: FolderConfig{LocalPath: "assets/models", Group: "assets"}},
+			check: func(t *testing.T, projectConfig *ProjectConfig) {
+				if _, exists := projectConfig.Folders["models"]; exists {
+					t.Errorf("the old key is still registered")
+				}
+				if _, exists := projectConfig.Folders["meshes"]; !exists {
+					t.Errorf("the new key is not registered")
+				}
+			},
+		},
+		{
+			name:   "edit folder keeps its key without a new one",
+			change: ConfigChange{Op: ChangeEditFolder, FolderKey: "models", Folder: FolderConfig{LocalPath: "assets/models", Group: "assets", Description: "Meshes"}},
+			check: func(t *testing.T, projectConfig *ProjectConfig) {
+				if got := projectConfig.Folders["models"].Description; got != "Meshes" {
+					t.Errorf("description = %q, want Meshes", got)
+				}
+			},
+		},
+		{
+			name:   "deregister folder",
+			change: ConfigChange{Op: ChangeDeregisterFolder, FolderKey: "textures"},
+			check: func(t *testing.T, projectConfig *ProjectConfig) {
+				if got := projectConfig.Folders["models"].SortOrder; got != 0 {
+					t.Errorf("models sort order = %d, want 0", got)
+				}
+				if got := projectConfig.Selections["lookdev"].Folders; len(got) != 0 {
+					t.Errorf("selection still references %v", got)
+				}
+			},
+		},
+		{
+			name:    "deregister missing folder",
+			change:  ConfigChange{Op: ChangeDeregisterFolder, FolderKey: "missing"},
+			wantErr: true,
+		},
+		{
+			name:   "create group last",
+			change: ConfigChange{Op: ChangeCreateGroup, GroupKey: "audio", Group: GroupConfig{Name: "Audio"}, Index: -1},
+			check: func(t *testing.T, projectConfig *ProjectConfig) {
+				if got := sortedChildGroups(projectConfig, ""); !reflect.DeepEqual(got, []string{"assets", "shots", "audio"}) {
+					t.Errorf("top-level groups = %v, want audio last", got)
+				}
+			},
+		},
+		{
+			name:    "create group with a missing parent",
+			change:  ConfigChange{Op: ChangeCreateGroup, GroupKey: "audio", Group: GroupConfig{Name: "Audio", ParentGroup: "missing"}},
+			wantErr: true,
+		},
+		{
+			name:   "update group",
+			change: ConfigChange{Op: ChangeUpdateGroup, GroupKey: "shots", Group: GroupConfig{Name: "Shots & Sequences"}, Index: 0},
+			check: func(t *testing.T, projectConfig *ProjectConfig) {
+				if got := sortedChildGroups(projectConfig, ""); !reflect.DeepEqual(got, []string{"shots", "assets"}) {
+					t.Errorf("top-level groups = %v, want shots first", got)
+				}
+			},
+		},
+		{
+			name:   "update group keeping its position",
+			change: ConfigChange{Op: ChangeUpdateGroup, GroupKey: "shots", Group: GroupConfig{Name: "Shots & Sequences", SortOrder: 0}, Index: -1},
+			check: func(t *testing.T, projectConfig *ProjectConfig) {
+				if got := sortedChildGroups(projectConfig, ""); !reflect.DeepEqual(got, []string{"assets", "shots"}) {
+					t.Errorf("top-level groups = %v, want shots to stay last", got)
+				}
+				if got := projectConfig.Groups["shots"].Name; got != "Shots & Sequences" {
+					t.Errorf("group name = %q, want it updated", got)
+				}
+			},
+		},
+		{
+			name:   "delete group reassigning its folders",
+			change: ConfigChange{Op: ChangeDeleteGroup, GroupKey: "assets", Mode: GroupDeleteReassign, TargetGroup: "shots"},
+			check: func(t *testing.T, projectConfig *ProjectConfig) {
+				if _, exists := projectConfig.Groups["assets"]; exists {
+					t.Errorf("the group still exists")
+				}
+				if got := projectConfig.Folders["textures"].Group; got != "shots" {
+					t.Errorf("textures is in group %q, want shots", got)
+				}
+			},
+		},
+		{
+			name:    "delete group with folders is refused",
+			change:  ConfigChange{Op: ChangeDeleteGroup, GroupKey: "assets"},
+			wantErr: true,
+		},
+		{
+			name:   "merge groups",
+			change: ConfigChange{Op: ChangeMergeGroups, GroupKey: "assets", TargetGroup: "seq01"},
+			check: func(t *testing.T, projectConfig *ProjectConfig) {
+				if got := sortedGroupFolders(projectConfig, "seq01"); !reflect.DeepEqual(got, []string{"textures", "models"}) {
+					t.Errorf("folders in seq01 = %v, want textures and models", got)
+				}
+				if got := projectConfig.Selections["lookdev"].Groups; !reflect.DeepEqual(got, []string{"seq01"}) {
+					t.Errorf("selection groups = %v, want seq01", got)
+				}
+			},
+		},
+		{
+			name:    "merge a group into its own child",
+			change:  ConfigChange{Op: ChangeMergeGroups, GroupKey: "shots", TargetGroup: "seq01"},
+			wantErr: true,
+		},
+		{
+			name:   "rename group",
+			change: ConfigChange{Op: ChangeRenameGroup, GroupKey: "shots", NewGroupKey: "sequences", Name: "Sequences"},
+			check: func(t *testing.T, projectConfig *ProjectConfig) {
+				if got := projectConfig.Groups["seq01"].ParentGroup; got != "sequences" {
+					t.Errorf("seq01 parent = %q, want sequences", got)
+				}
+			},
+		},
+		{
+			name:   "move group",
+			change: ConfigChange{Op: ChangeMoveGroup, GroupKey: "seq01", Index: 0},
+			check: func(t *testing.T, projectConfig *ProjectConfig) {
+				if got := sortedChildGroups(projectConfig, ""); !reflect.DeepEqual(got, []string{"seq01", "assets", "shots"}) {
+					t.Errorf("top-level groups = %v, want seq01 first", got)
+				}
+			},
+		},
+		{
+			name:    "move group into itself",
+			change:  ConfigChange{Op: ChangeMoveGroup, GroupKey: "shots", TargetGroup: "seq01"},
+			wantErr: true,
+		},
+		{
+			name:   "reorder groups",
+			change: ConfigChange{Op: ChangeReorderGroups, OrderedKeys: []string{"shots", "assets"}},
+			check: func(t *testing.T, projectConfig *ProjectConfig) {
+				if got := sortedChildGroups(projectConfig, ""); !reflect.DeepEqual(got, []string{"shots", "assets"}) {
+					t.Errorf("top-level groups = %v, want shots first", got)
+				}
+			},
+		},
+		{
+			name:    "reorder groups with a missing key",
+			change:  ConfigChange{Op: ChangeReorderGroups, OrderedKeys: []string{"shots"}},
+			wantErr: true,
+		},
+		{
+			name:   "reorder folders",
+			change: ConfigChange{Op: ChangeReorderFolders, GroupKey: "assets", OrderedKeys: []string{"models", "textures"}},
+			check: func(t *testing.T, projectConfig *ProjectConfig) {
+				if got := sortedGroupFolders(projectConfig, "assets"); !reflect.DeepEqual(got, []string{"models", "textures"}) {
+					t.Errorf("folders in assets = %v, want models first", got)
+				}
+			},
+		},
+		{
+			name:   "save selection",
+			change: ConfigChange{Op: ChangeSaveSelection, SelectionKey: "hero", Selection: SelectionConfig{TagQueries: []string{"Hero + Final"}}},
+			check: func(t *testing.T, projectConfig *ProjectConfig) {
+				if got := projectConfig.Selections["hero"].Name; got != "hero" {
+					t.Errorf("selection name = %q, want the key", got)
+				}
+			},
+		},
+		{
+			name:    "save empty selection",
+			change:  ConfigChange{Op: ChangeSaveSelection, SelectionKey: "empty"},
+			wantErr: true,
+		},
+		{
+			name:   "delete selection",
+			change: ConfigChange{Op: ChangeDeleteSelection, SelectionKey: "lookdev"},
+			check: func(t *testing.T, projectConfig *ProjectConfig) {
+				if _, exists := projectConfig.Selections["lookdev"]; exists {
+					t.Errorf("the selection still exists")
+				}
+			},
+		},
+		{
+			name:    "unknown operation",
+			change:  ConfigChange{Op: "rename_project"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			projectConfig := newChangeTestConfig()
+			err := applyConfigChange(projectConfig, projectRoot, tt.change)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("applyConfigChange() error = %v, want error %v", err, tt.wantErr)
+			}
+			if tt.check != nil {
+				tt.check(t, projectConfig)
+			}
+		})
+	}
+}
@@ -8,10 +8,20 @@ import (
 	"sync"
 )
 
+// ConfigManager holds the current global and project configs. Both are treated as immutable
+// snapshots: getters return deep copies, and changes are made on a copy that then replaces the
+// current snapshot, so readers never see a config while it is being modified.
 type ConfigManager struct {
-	globalConfig  *GlobalConfig
-	projectConfig *ProjectConfig
-	mu            sync.RWMutex // Protects against race conditions
+	globalConfig    *GlobalConfig
+	projectConfig   *ProjectConfig
+	credentialStore CredentialStore
+	mu              sync.RWMutex // Protects against race conditions
+	globalUpdateMu  sync.Mutex   // Serializes read-modify-write updates of the global config
+	projectUpdateMu sync.Mutex   // Serializes read-modify-write updates of the project config
+	configOutbox    *configOutbox
+	configUploadMu  sync.Mutex // Serializes sync.json uploads
+	bandwidth       bandwidthState
+	folderState     *folderStateStore // Shared by the folder and sync services
 }
 
 func NewConfigManager(global *GlobalConfig, project *ProjectConfig) *ConfigManager {
@@ -26,17 +36,20 @@ func NewConfigManager(global *GlobalConfig, project *ProjectConfig) *ConfigManag
 	return &ConfigManager{
 		globalConfig:  global,
 		projectConfig: project,
+		configOutbox:  newConfigOutbox(),
+		folderState:   newFolderStateStore(),
 	}
 }
 
-func (cm *ConfigManager) getDefaultConfigPath() (string, error) {
+// getAppConfigDir returns the directory holding the app's own configuration files,
+// creating it if it doesn't exist yet.
+func getAppConfigDir() (string, error) {
 	homeDir, err := os.UserHomeDir()
 	if err != nil {
 		return "", fmt.Errorf("failed to get user home directory: %v", err)
 	}
 
 	configDir := filepath.Join(homeDir, ".config", "rclone-selective-sync")
-	configFile := filepath.Join(configDir, "config.json")
 
 	if _, dirErr := os.Stat(configDir); os.IsNotExist(dirErr) {
 		if mkdirErr := os.MkdirAll(configDir, 0644); mkdirErr != nil {
@@ -44,6 +57,27 @@ func (cm *ConfigManager) getDefaultConfigPath() (string, error) {
 		}
 	}
 
+	return configDir, nil
+}
+
+// getLegacyRcloneConfigPath returns the path of the app-private rclone.conf that earlier versions
+// wrote their remotes to. The remotes now only live in rclone's in-memory config.
+func getLegacyRcloneConfigPath() (string, error) {
+	configDir, err := getAppConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(configDir, "rclone.conf"), nil
+}
+
+func (cm *ConfigManager) getDefaultConfigPath() (string, error) {
+	configDir, err := getAppConfigDir()
+	if err != nil {
+		return "", err
+	}
+
+	configFile := filepath.Join(configDir, "config.json")
+
 	if _, fileErr := os.Stat(configFile); os.IsNotExist(fileErr) {
 		defaultConfig := &GlobalConfig{}
 		if saveErr := saveConfig(configFile, defaultConfig); saveErr != nil {
@@ -57,12 +91,16 @@ func (cm *ConfigManager) getDefaultConfigPath() (string, error) {
 
 // WriteGlobalConfigToDisk writes the global configuration to its file on disk.
 func (cm *ConfigManager) WriteGlobalConfigToDisk() error {
+	return cm.writeGlobalConfig(cm.GetGlobalConfig())
+}
+
+// writeGlobalConfig writes the given global configuration to the config file on disk.
+func (cm *ConfigManager) writeGlobalConfig(globalConfig *GlobalConfig) error {
 	configFilePath, err := cm.getDefaultConfigPath()
 	if err != nil {
 		return fmt.Errorf("failed to get config file path: %v", err)
 	}
 
-	globalConfig := cm.GetGlobalConfig()
 	if err := saveConfig(configFilePath, globalConfig); err != nil {
 		return fmt.Errorf("failed to save global config to disk: %v", err)
 	}
@@ -70,18 +108,19 @@ func (cm *ConfigManager) WriteGlobalConfigToDisk() error {
 	return nil
 }
 
-// GetGlobalConfig provides a thread-safe way to access the global config
+// GetGlobalConfig returns a copy of the global config that the caller is free to modify
 func (cm *ConfigManager) GetGlobalConfig() *GlobalConfig {
 	cm.mu.RLock()
 	defer cm.mu.RUnlock()
-	return cm.globalConfig
+	return cm.globalConfig.Clone()
 }
 
 // Set the selected project
 func (cm *ConfigManager) SetGlobalConfigSelectedProject(selectedProject string) {
-	cm.mu.RLock()
-	defer cm.mu.RUnlock()
-	cm.globalConfig.SelectedProject = selectedProject
+	cm.UpdateGlobalConfig(func(globalConfig *GlobalConfig) error {
+		globalConfig.SelectedProject = selectedProject
+		return nil
+	})
 }
 
 // Get the selected project
@@ -101,25 +140,87 @@ func (cm *ConfigManager) GetSelectedProjectRemoteConfig() *RemoteConfig {
 	return nil // Handle the case where the SelectedProject does not exist
 }
 
-// SetGlobalConfig updates the global config
+// SetGlobalConfig replaces the global config with a copy of the given one
 func (cm *ConfigManager) SetGlobalConfig(global *GlobalConfig) {
+	cm.globalUpdateMu.Lock()
+	defer cm.globalUpdateMu.Unlock()
 	cm.mu.Lock()
 	defer cm.mu.Unlock()
-	cm.globalConfig = global
+	cm.globalConfig = global.Clone()
 }
 
-// GetProjectConfig provides a thread-safe way to access the project config
+// UpdateGlobalConfig applies a change to a copy of the global config. If the change succeeds, the
+// copy replaces the current global config and a copy of it is returned. Updates are serialized,
+// so concurrent updates never overwrite each other.
+func (cm *ConfigManager) UpdateGlobalConfig(change func(globalConfig *GlobalConfig) error) (*GlobalConfig, error) {
+	cm.globalUpdateMu.Lock()
+	defer cm.globalUpdateMu.Unlock()
+
+	updatedConfig := cm.GetGlobalConfig()
+	if err := change(updatedConfig); err != nil {
+		return nil, err
+	}
+
+	cm.mu.Lock()
+	cm.globalConfig = updatedConfig
+	cm.mu.Unlock()
+	return updatedConfig.Clone(), nil
+}
+
+// GetCredentialStore returns the store holding the remotes' secrets
+func (cm *ConfigManager) GetCredentialStore() CredentialStore {
+	cm.mu.RLock()
+	defer cm.mu.RUnlock()
+	return cm.credentialStore
+}
+
+// SetCredentialStore updates the store holding the remotes' secrets
+func (cm *ConfigManager) SetCredentialStore(store CredentialStore) {
+	cm.mu.Lock()
+	defer cm.mu.Unlock()
+	cm.credentialStore = store
+}
+
+// GetProjectConfig returns a copy of the project config that the caller is free to modify
 func (cm *ConfigManager) GetProjectConfig() *ProjectConfig {
 	cm.mu.RLock()
 	defer cm.mu.RUnlock()
-	return cm.projectConfig
+	if cm.projectConfig == nil {
+		return nil
+	}
+	return cm.projectConfig.Clone()
 }
 
-// SetProjectConfig updates the project config
+// SetProjectConfig replaces the project config with a copy of the given one
 func (cm *ConfigManager) SetProjectConfig(project *ProjectConfig) {
+	cm.projectUpdateMu.Lock()
+	defer cm.projectUpdateMu.Unlock()
 	cm.mu.Lock()
 	defer cm.mu.Unlock()
-	cm.projectConfig = project
+	cm.projectConfig = project.Clone()
+}
+
+// UpdateProjectConfig applies a change to a copy of the project config. If the change succeeds,
+// the copy replaces the current project config and a copy of it is returned. Updates are
+// serialized, so concurrent updates never overwrite each other, while readers keep working on
+// the previous snapshot until the update is done. The change must not call back into the
+// ConfigManager's setters or update methods.
+func (cm *ConfigManager) UpdateProjectConfig(change func(projectConfig *ProjectConfig) error) (*ProjectConfig, error) {
+	cm.projectUpdateMu.Lock()
+	defer cm.projectUpdateMu.Unlock()
+
+	updatedConfig := cm.GetProjectConfig()
+	if updatedConfig == nil {
+		return nil, fmt.Errorf("no project config is loaded")
+	}
+	if err := change(updatedConfig); err != nil {
+		return nil, err
+	}
+
+	cm.mu.Lock()
+	cm.projectConfig = updatedConfig
+	cm.mu.Unlock()
+	return updatedConfig.Clone(), nil
 }
 
 // Utility method to marshal a config object to JSON, ostensibly before sending to the front end.
@@ -131,16 +232,10 @@ func MarshalToJSON(object any) (string, error) {
 	return string(jsonConfig), nil
 }
 
-// Rclone copy the sync.json file to the remote
+// Rclone copy the selected project's sync.json file to the remote. A failed upload is queued and
+// retried in the background.
 func (cs *ConfigManager) syncConfigToRemote() error {
-	projectRemoteConfig := cs.GetSelectedProjectRemoteConfig()
-	projectPath := projectRemoteConfig.LocalPath
-	dstFs := fmt.Sprintf("%s:%s", projectRemoteConfig.RemoteName, projectRemoteConfig.BucketName)
-
-	if err := RcloneCopyFile(projectPath, "sync.json", dstFs, "sync.json"); err != nil {
-		return fmt.Errorf("rclone copyfile failed: %v", err)
-	}
-	return nil
+	return cs.pushProjectConfig(cs.GetSelectedProject())
 }
 
 // saveConfig writes the Config struct to a file in JSON format
@@ -0,0 +1,107 @@
+package backend
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+)
+
+// newTestConfigManager returns a ConfigManager with a selected project rooted in a temp dir.
+func newTestConfigManager(t *testing.T, projectConfig *ProjectConfig) *ConfigManager {
+	t.Helper()
+	globalConfig := &GlobalConfig{
+		SelectedProject: "demo",
+		Remotes: map[string]RemoteConfig{
+			"demo": {RemoteName: "demo", BucketName: "demo-bucket", Type: "b2", LocalPath: t.TempDir()},
+		},
+	}
+	return NewConfigManager(globalConfig, projectConfig)
+}
+
+// TestConfigManagerConcurrentAccess updates the project config while readers and the sync
+// service's job builders use it. Run it with -race; it also checks that no update is lost.
+func TestConfigManagerConcurrentAccess(t *testing.T) {
+	cm := newTestConfigManager(t, &ProjectConfig{
+		AllowGlobalSync: true,
+		Folders: map[string]FolderConfig{
+			"textures": {LocalPath: "assets/textures", RemotePath: "assets/textures", Group: "assets"},
+		},
+		Groups: map[string]GroupConfig{
+			"assets": {Name: "Assets"},
+			"extra":  {Name: "Extra", ParentGroup: "assets"},
+		},
+		Selections: map[string]SelectionConfig{
+			"all-assets": {Name: "All assets", Groups: []string{"assets"}},
+		},
+	})
+	ss := NewSyncService(cm)
+
+	const writers, updatesPerWriter, readers = 4, 50, 4
+	var wg sync.WaitGroup
+	for w := 0; w < writers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < updatesPerWriter; i++ {
+				folderKey := fmt.Sprintf("extra-%d-%d", w, i)
+				_, err := cm.UpdateProjectConfig(func(projectConfig *ProjectConfig) error {
+					folderPath := "extra/" + folderKey
+					projectConfig.Folders[folderKey] = FolderConfig{LocalPath: folderPath, RemotePath: folderPath, Group: "extra"}
+					placeFolder(projectConfig, folderKey, "extra", "extra", -1)
+					return nil
+				})
+				if err != nil {
+					t.Errorf("UpdateProjectConfig failed: %v", err)
+					return
+				}
+			}
+		}(w)
+	}
+	for r := 0; r < readers; r++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < updatesPerWriter; i++ {
+				// Readers get their own copy, which they are free to change
+				projectConfig := cm.GetProjectConfig()
+				for folderKey, folderConfig := range projectConfig.Folders {
+					folderConfig.Tags = append(folderConfig.Tags, "seen")
+					projectConfig.Folders[folderKey] = folderConfig
+				}
+				delete(projectConfig.Groups, "extra")
+
+				if _, err := ss.projectPullJobs(false, true); err != nil {
+					t.Errorf("projectPullJobs failed: %v", err)
+					return
+				}
+				if _, err := ss.groupActionFolders("assets", COPY_PULL); err != nil {
+					t.Errorf("groupActionFolders failed: %v", err)
+					return
+				}
+				if _, err := resolveTargetFolders(cm.GetProjectConfig(), nil, "all-assets"); err != nil {
+					t.Errorf("resolveTargetFolders failed: %v", err)
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	projectConfig := cm.GetProjectConfig()
+	if got, want := len(projectConfig.Folders), 1+writers*updatesPerWriter; got != want {
+		t.Errorf("project has %d folders, want %d", got, want)
+	}
+	if _, exists := projectConfig.Groups["extra"]; !exists {
+		t.Errorf("a reader's change to its copy leaked into the project config")
+	}
+	for folderKey, folderConfig := range projectConfig.Folders {
+		if len(folderConfig.Tags) != 0 {
+			t.Errorf("folder %q has tags %v from a reader's copy", folderKey, folderConfig.Tags)
+		}
+	}
+	for i, folderKey := range sortedGroupFolders(projectConfig, "extra") {
+		if projectConfig.Folders[folderKey].SortOrder != i {
+			t.Errorf("folder %q has sort order %d, want %d", folderKey, projectConfig.Folders[folderKey].SortOrder, i)
+		}
+	}
+}
@@ -0,0 +1,426 @@
+package backend
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Backoff between attempts to upload a queued sync.json.
+const (
+	configSyncInitialBackoff = 10 * time.Second
+	configSyncMaxBackoff     = 10 * time.Minute
+)
+
+// PendingConfigSync is a project whose sync.json was saved locally but not uploaded to the remote
+// yet. The upload always sends the current local sync.json, so any number of queued changes are
+// pushed together. If the remote sync.json is no longer the version the queued changes were based
+// on, the upload is held back as a conflict instead, so neither side's edits are overwritten.
+type PendingConfigSync struct {
+	Project       string           `json:"project"`
+	QueuedAt      string           `json:"queued_at"`       // RFC3339; when the first failed upload was queued
+	Attempts      int              `json:"attempts"`        // Failed upload attempts so far
+	LastAttemptAt string           `json:"last_attempt_at"` // RFC3339
+	NextAttemptAt string           `json:"next_attempt_at"` // RFC3339
+	LastError     string           `json:"last_error"`
+	BaseRemote    *RemoteFileState `json:"base_remote"`     // The remote sync.json this machine last pushed or pulled before the upload was queued; nil if unknown
+	Conflict      bool             `json:"conflict"`        // Retries are paused until the conflict is resolved
+	RemoteModTime string           `json:"remote_mod_time"` // RFC3339; the remote sync.json's mod time when the conflict was found
+}
+
+// errConfigSyncConflict is returned for an upload that is held back by a conflict.
+var errConfigSyncConflict = errors.New("the remote sync.json was changed by someone else while this upload was queued")
+
+// configOutbox persists the pending sync.json uploads, keyed by project, in config_outbox.json
+// next to config.json, so uploads that failed before the app was closed are retried on the next
+// start. It is loaded on first use. It also remembers, for this session, the remote sync.json each
+// project last pushed or pulled, which becomes the base of an upload that gets queued.
+type configOutbox struct {
+	pending  map[string]PendingConfigSync
+	known    map[string]RemoteFileState
+	loaded   bool
+	retrying bool // Whether the retry loop is running
+	mu       sync.Mutex
+}
+
+func newConfigOutbox() *configOutbox {
+	return &configOutbox{pending: make(map[string]PendingConfigSync), known: make(map[string]RemoteFileState)}
+}
+
+// setKnownRemote remembers the remote sync.json the project last pushed or pulled.
+func (co *configOutbox) setKnownRemote(project string, state RemoteFileState) {
+	co.mu.Lock()
+	defer co.mu.Unlock()
+	co.known[project] = state
+}
+
+// forgetKnownRemote drops the remembered remote sync.json, when its state couldn't be read.
+func (co *configOutbox) forgetKnownRemote(project string) {
+	co.mu.Lock()
+	defer co.mu.Unlock()
+	delete(co.known, project)
+}
+
+// list returns the pending uploads, sorted by project.
+func (co *configOutbox) list() []PendingConfigSync {
+	co.mu.Lock()
+	defer co.mu.Unlock()
+	co.load()
+	return co.listLocked()
+}
+
+func (co *configOutbox) listLocked() []PendingConfigSync {
+	entries := make([]PendingConfigSync, 0, len(co.pending))
+	for _, entry := range co.pending {
+		entries = append(entries, entry)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Project < entries[j].Project
+	})
+	return entries
+}
+
+// has reports whether the project has an upload pending.
+func (co *configOutbox) has(project string) bool {
+	_, exists := co.get(project)
+	return exists
+}
+
+// get returns the project's pending upload, if it has one.
+func (co *configOutbox) get(project string) (PendingConfigSync, bool) {
+	co.mu.Lock()
+	defer co.mu.Unlock()
+	co.load()
+	entry, exists := co.pending[project]
+	return entry, exists
+}
+
+// recordConflict holds the project's upload back until the conflict with the remote sync.json,
+// last modified at remoteModTime, is resolved. The upload is queued if it wasn't already.
+func (co *configOutbox) recordConflict(project string, remoteModTime time.Time) {
+	co.mu.Lock()
+	defer co.mu.Unlock()
+	co.load()
+
+	now := time.Now().UTC()
+	entry, exists := co.pending[project]
+	if !exists {
+		entry = PendingConfigSync{Project: project, QueuedAt: now.Format(time.RFC3339)}
+	}
+	entry.Conflict = true
+	entry.RemoteModTime = remoteModTime.UTC().Format(time.RFC3339)
+	entry.LastAttemptAt = now.Format(time.RFC3339)
+	entry.NextAttemptAt = ""
+	entry.LastError = errConfigSyncConflict.Error()
+	co.pending[project] = entry
+	co.saveLocked()
+}
+
+// retryableLocked returns how many pending uploads are not held back by a conflict.
+func (co *configOutbox) retryableLocked() int {
+	count := 0
+	for _, entry := range co.pending {
+		if !entry.Conflict {
+			count++
+		}
+	}
+	return count
+}
+
+// recordFailure queues the project's upload, based on the remote sync.json it last pushed or
+// pulled, or schedules the next attempt of an upload that is already queued, with exponential
+// backoff.
+func (co *configOutbox) recordFailure(project string, uploadErr error) {
+	co.mu.Lock()
+	defer co.mu.Unlock()
+	co.load()
+
+	now := time.Now().UTC()
+	entry, exists := co.pending[project]
+	if !exists {
+		entry = PendingConfigSync{Project: project, QueuedAt: now.Format(time.RFC3339)}
+		if known, ok := co.known[project]; ok {
+			entry.BaseRemote = &known
+		}
+	}
+	entry.Attempts++
+	backoff := configSyncInitialBackoff << min(entry.Attempts-1, 16)
+	if backoff > configSyncMaxBackoff {
+		backoff = configSyncMaxBackoff
+	}
+	entry.LastAttemptAt = now.Format(time.RFC3339)
+	entry.NextAttemptAt = now.Add(backoff).Format(time.RFC3339)
+	entry.LastError = uploadErr.Error()
+	co.pending[project] = entry
+	co.saveLocked()
+}
+
+// remove drops the project's pending upload. Returns whether one was pending.
+func (co *configOutbox) remove(project string) bool {
+	co.mu.Lock()
+	defer co.mu.Unlock()
+	co.load()
+
+	if _, exists := co.pending[project]; !exists {
+		return false
+	}
+	delete(co.pending, project)
+	co.saveLocked()
+	return true
+}
+
+// nextAttempt returns the projects whose next attempt is due, and how long until the earliest
+// attempt that isn't. ok is false when nothing is pending, or only conflicts are.
+func (co *configOutbox) nextAttempt() (due []string, wait time.Duration, ok bool) {
+	co.mu.Lock()
+	defer co.mu.Unlock()
+	co.load()
+
+	now := time.Now().UTC()
+	wait = configSyncMaxBackoff
+	for project, entry := range co.pending {
+		if entry.Conflict {
+			continue
+		}
+		next, err := time.Parse(time.RFC3339, entry.NextAttemptAt)
+		if err != nil || !next.After(now) {
+			due = append(due, project)
+		} else if next.Sub(now) < wait {
+			wait = next.Sub(now)
+		}
+	}
+	sort.Strings(due)
+	return due, wait, co.retryableLocked() > 0
+}
+
+// load reads the outbox from disk once. A missing file starts an empty outbox.
+func (co *configOutbox) load() {
+	if co.loaded {
+		return
+	}
+	co.loaded = true
+
+	path, err := configOutboxPath()
+	if err != nil {
+		return
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return
+	}
+	if err := json.Unmarshal(data, &co.pending); err != nil || co.pending == nil {
+		fmt.Printf("Warning: Ignoring unreadable config outbox: %v\n", err)
+		co.pending = make(map[string]PendingConfigSync)
+	}
+}
+
+func (co *configOutbox) saveLocked() {
+	path, err := configOutboxPath()
+	if err == nil {
+		err = saveConfig(path, co.pending)
+	}
+	if err != nil {
+		fmt.Printf("Warning: Failed to save config outbox: %v\n", err)
+	}
+}
+
+// configOutboxPath returns the path of config_outbox.json in the app config directory.
+func configOutboxPath() (string, error) {
+	configDir, err := getAppConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(configDir, "config_outbox.json"), nil
+}
+
+// pushProjectConfig uploads the project's local sync.json to its remote. If the upload fails, it
+// is queued in the outbox and retried in the background until it succeeds. Uploads are serialized,
+// so the last one to run always sends the latest sync.json. A queued upload is only retried while
+// the remote sync.json is still the one it was based on; otherwise it is held back as a conflict.
+func (cm *ConfigManager) pushProjectConfig(project string) error {
+	return cm.uploadProjectConfig(project, false)
+}
+
+// uploadProjectConfig uploads the project's local sync.json. With force, the upload goes ahead
+// even if it conflicts with the remote sync.json.
+func (cm *ConfigManager) uploadProjectConfig(project string, force bool) error {
+	remoteConfig, exists := cm.GetGlobalConfig().Remotes[project]
+	if !exists {
+		// The project was removed; there is nowhere to upload to any more
+		if cm.configOutbox.remove(project) {
+			cm.emitPendingConfigSync()
+		}
+		return fmt.Errorf("project '%s' is not configured", project)
+	}
+
+	cm.configUploadMu.Lock()
+	err := cm.checkQueuedConfigUpload(project, remoteConfig, force)
+	if err == nil {
+		err = RcloneCopyFile(remoteConfig.LocalPath, "sync.json", remoteConfig.remoteRoot(), "sync.json")
+	}
+	if err == nil {
+		cm.recordKnownRemoteSyncFile(project, remoteConfig)
+	}
+	cm.configUploadMu.Unlock()
+
+	if errors.Is(err, errConfigSyncConflict) {
+		return err
+	}
+	if err != nil {
+		cm.configOutbox.recordFailure(project, err)
+		cm.emitPendingConfigSync()
+		cm.startConfigSyncRetries()
+		return fmt.Errorf("rclone copyfile failed: %v", err)
+	}
+	if cm.configOutbox.remove(project) {
+		fmt.Printf("Uploaded queued sync.json for project '%s'\n", project)
+		cm.emitPendingConfigSync()
+	}
+	return nil
+}
+
+// checkQueuedConfigUpload returns errConfigSyncConflict if the project has a queued upload and the
+// remote sync.json is no longer the one the upload was based on, which means someone else edited
+// the project in the meantime. The remote's mod time can't tell, since rclone keeps the mod time of
+// the uploader's local file. If the base is unknown, the upload only goes ahead when the remote
+// already matches the local sync.json. The conflict is recorded and reported, and the upload is
+// held back until it is resolved by keeping either the local or the remote sync.json.
+func (cm *ConfigManager) checkQueuedConfigUpload(project string, remoteConfig RemoteConfig, force bool) error {
+	entry, queued := cm.configOutbox.get(project)
+	if !queued || force {
+		return nil
+	}
+	if entry.Conflict {
+		return fmt.Errorf("%w; keep the local or the remote sync.json to resolve it", errConfigSyncConflict)
+	}
+
+	remoteState, err := RcloneStatRemoteFile(remoteConfig.remoteRoot(), "sync.json")
+	if errors.Is(err, errRemoteFileNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to check the remote sync.json: %v", err)
+	}
+	baseState := entry.BaseRemote
+	if baseState == nil {
+		localState, err := RcloneStatRemoteFile(remoteConfig.LocalPath, "sync.json")
+		if err != nil {
+			return fmt.Errorf("failed to check the local sync.json: %v", err)
+		}
+		baseState = &localState
+	}
+	if baseState.sameVersion(remoteState) {
+		return nil
+	}
+	remoteModTime, _ := remoteState.modTime()
+	cm.raiseConfigSyncConflict(project, remoteConfig, remoteModTime)
+	return errConfigSyncConflict
+}
+
+// recordKnownRemoteSyncFile remembers the project's remote sync.json after a push or pull, as the
+// base of any upload that gets queued later.
+func (cm *ConfigManager) recordKnownRemoteSyncFile(project string, remoteConfig RemoteConfig) {
+	state, err := RcloneStatRemoteFile(remoteConfig.remoteRoot(), "sync.json")
+	if err != nil {
+		fmt.Printf("Warning: Failed to read the state of the remote sync.json: %v\n", err)
+		cm.configOutbox.forgetKnownRemote(project)
+		return
+	}
+	cm.configOutbox.setKnownRemote(project, state)
+}
+
+// raiseConfigSyncConflict holds the project's queued upload back and tells the frontend that its
+// local and remote sync.json both changed.
+func (cm *ConfigManager) raiseConfigSyncConflict(project string, remoteConfig RemoteConfig, remoteModTime time.Time) {
+	cm.configOutbox.recordConflict(project, remoteModTime)
+	fmt.Printf("[WARN] sync.json of project '%s' changed on the remote while local changes were queued; holding the upload back\n", project)
+
+	localModTime := ""
+	if info, err := os.Stat(filepath.Join(remoteConfig.LocalPath, "sync.json")); err == nil {
+		localModTime = info.ModTime().UTC().Format(time.RFC3339)
+	}
+	emitEvent(EventConfigSyncConflict, ConfigSyncConflictPayload{
+		Project:       project,
+		Message:       "The project configuration was changed on the remote while your changes were waiting to be uploaded. Keep your version or the remote one; the other will be overwritten.",
+		LocalModTime:  localModTime,
+		RemoteModTime: remoteModTime.UTC().Format(time.RFC3339),
+	})
+	cm.emitPendingConfigSync()
+}
+
+// startConfigSyncRetries starts the background retry loop if any upload is pending and the loop
+// isn't running yet.
+func (cm *ConfigManager) startConfigSyncRetries() {
+	if _, _, ok := cm.configOutbox.nextAttempt(); !ok {
+		return
+	}
+	co := cm.configOutbox
+	co.mu.Lock()
+	defer co.mu.Unlock()
+	if co.retrying {
+		return
+	}
+	co.retrying = true
+	go cm.retryPendingConfigSyncs()
+}
+
+// retryPendingConfigSyncs retries every pending upload when it is due, until none are left.
+func (cm *ConfigManager) retryPendingConfigSyncs() {
+	co := cm.configOutbox
+	for {
+		due, wait, ok := co.nextAttempt()
+		if !ok {
+			co.mu.Lock()
+			// Check again under the lock, so a failure recorded just now isn't left without a loop
+			if co.retryableLocked() == 0 {
+				co.retrying = false
+				co.mu.Unlock()
+				return
+			}
+			co.mu.Unlock()
+			continue
+		}
+		for _, project := range due {
+			cm.pushProjectConfig(project)
+		}
+		if len(due) > 0 {
+			continue
+		}
+		time.Sleep(wait)
+	}
+}
+
+// emitPendingConfigSync tells the frontend which uploads are pending.
+func (cm *ConfigManager) emitPendingConfigSync() {
+	emitEvent(EventConfigSyncPending, ConfigSyncPendingPayload{Pending: cm.configOutbox.list()})
+}
+
+// GetPendingConfigSync returns the projects whose sync.json changes haven't reached the remote yet.
+func (cs *ConfigService) GetPendingConfigSync() []PendingConfigSync {
+	return cs.configManager.configOutbox.list()
+}
+
+// RetryPendingConfigSync retries every pending sync.json upload right away, without waiting for
+// the backoff, and returns the uploads that are still pending afterwards. Uploads held back by a
+// conflict stay pending.
+func (cs *ConfigService) RetryPendingConfigSync() []PendingConfigSync {
+	for _, entry := range cs.configManager.configOutbox.list() {
+		cs.configManager.pushProjectConfig(entry.Project)
+	}
+	return cs.configManager.configOutbox.list()
+}
+
+// KeepLocalSyncFile resolves a conflict in favor of the selected project's local sync.json, by
+// uploading it over the remote one. To keep the remote one instead, use RefreshSyncFile.
+func (cs *ConfigService) KeepLocalSyncFile() error {
+	selectedProject := cs.configManager.GetSelectedProject()
+	if selectedProject == "" {
+		return errors.New("no project selected")
+	}
+	return cs.configManager.uploadProjectConfig(selectedProject, true)
+}
@@ -0,0 +1,102 @@
+package backend
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestQueuedConfigUploadConflict(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	initTestRclone()
+	if err := RcloneCreateRemote("outboxlocal", "local", map[string]string{}); err != nil {
+		t.Fatalf("failed to create local remote: %v", err)
+	}
+
+	bucketDir, localDir := t.TempDir(), t.TempDir()
+	cm := NewConfigManager(&GlobalConfig{
+		SelectedProject: "demo",
+		Remotes: map[string]RemoteConfig{
+			"demo": {RemoteName: "outboxlocal", BucketName: bucketDir, LocalPath: localDir},
+		},
+	}, &ProjectConfig{})
+	writeSyncFile := func(dir string, content string, modTime time.Time) {
+		t.Helper()
+		path := filepath.Join(dir, "sync.json")
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Chtimes(path, modTime, modTime); err != nil {
+			t.Fatal(err)
+		}
+	}
+	remoteContent := func() string {
+		t.Helper()
+		data, err := os.ReadFile(filepath.Join(bucketDir, "sync.json"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return string(data)
+	}
+
+	remoteConfig := cm.GetGlobalConfig().Remotes["demo"]
+
+	// The remote is still the one this machine last pulled, so the retry goes ahead
+	writeSyncFile(bucketDir, `{"version":"remote"}`, time.Now().Add(-time.Hour))
+	cm.recordKnownRemoteSyncFile("demo", remoteConfig)
+	writeSyncFile(localDir, `{"version":"local"}`, time.Now().Add(-time.Minute))
+	cm.configOutbox.recordFailure("demo", errors.New("offline"))
+	if err := cm.pushProjectConfig("demo"); err != nil {
+		t.Fatalf("retry of an unchanged remote failed: %v", err)
+	}
+	if got := remoteContent(); got != `{"version":"local"}` {
+		t.Errorf("remote sync.json = %s, want the local one", got)
+	}
+	if cm.configOutbox.has("demo") {
+		t.Errorf("the upload is still queued after it succeeded")
+	}
+
+	// Someone else uploads while the upload is queued. Their edit was made before it was queued,
+	// and rclone keeps that mod time on upload, but the retry is still held back.
+	cm.configOutbox.recordFailure("demo", errors.New("offline"))
+	writeSyncFile(localDir, `{"version":"local edit"}`, time.Now())
+	writeSyncFile(bucketDir, `{"version":"other machine"}`, time.Now().Add(-30*time.Minute))
+	if err := cm.pushProjectConfig("demo"); !errors.Is(err, errConfigSyncConflict) {
+		t.Fatalf("retry over a changed remote returned %v, want a conflict", err)
+	}
+	if got := remoteContent(); got != `{"version":"other machine"}` {
+		t.Errorf("remote sync.json = %s, want it untouched", got)
+	}
+	entry, queued := cm.configOutbox.get("demo")
+	if !queued || !entry.Conflict {
+		t.Fatalf("outbox entry = %+v, queued %v; want it kept as a conflict", entry, queued)
+	}
+	if _, _, ok := cm.configOutbox.nextAttempt(); ok {
+		t.Errorf("a conflict is still scheduled for retries")
+	}
+	if err := cm.pushProjectConfig("demo"); !errors.Is(err, errConfigSyncConflict) {
+		t.Errorf("second retry returned %v, want a conflict", err)
+	}
+
+	// Keeping the local copy overwrites the remote and clears the conflict
+	if err := cm.uploadProjectConfig("demo", true); err != nil {
+		t.Fatalf("forced upload failed: %v", err)
+	}
+	if got := remoteContent(); got != `{"version":"local edit"}` {
+		t.Errorf("remote sync.json = %s, want the local one", got)
+	}
+	if cm.configOutbox.has("demo") {
+		t.Errorf("the conflict is still queued after keeping the local copy")
+	}
+
+	// Without a known base, as after starting offline, a remote that differs from the local copy
+	// is a conflict
+	cm.configOutbox.forgetKnownRemote("demo")
+	writeSyncFile(localDir, `{"version":"offline edit"}`, time.Now())
+	cm.configOutbox.recordFailure("demo", errors.New("offline"))
+	if err := cm.pushProjectConfig("demo"); !errors.Is(err, errConfigSyncConflict) {
+		t.Errorf("retry without a known base returned %v, want a conflict", err)
+	}
+}
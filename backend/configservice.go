@@ -7,6 +7,7 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"sort"
 	"time"
 )
 
@@ -25,6 +26,11 @@ func (cs *ConfigService) SetSelectedProject(selectedProject string) error {
 	var err error
 	cs.configManager.SetGlobalConfigSelectedProject(selectedProject)
 	cs.configManager.WriteGlobalConfigToDisk()
+	// The selected project may have its own transfer profile and bandwidth limit
+	cs.configManager.applyTransferProfile()
+	if applyErr := cs.configManager.applyBandwidthLimit(); applyErr != nil {
+		fmt.Printf("Warning: Failed to apply bandwidth limit: %v\n", applyErr)
+	}
 	return err
 }
 
@@ -40,7 +46,7 @@ func (cs *ConfigService) LoadSelectedProjectConfig() (ProjectConfig, error) {
 		return *defaultConfig, errors.New("no project was selected; cannot load config")
 	}
 
-	remoteConfig := cs.configManager.globalConfig.Remotes[selectedProject]
+	remoteConfig := cs.configManager.GetGlobalConfig().Remotes[selectedProject]
 	projectPath := remoteConfig.LocalPath
 	configFile := filepath.Join(projectPath, "sync.json")
 
@@ -56,9 +62,6 @@ func (cs *ConfigService) LoadSelectedProjectConfig() (ProjectConfig, error) {
 	localFileInfo, fileErr := os.Stat(configFile)
 	localExists := !os.IsNotExist(fileErr)
 
-	// Build the remote path for sync.json
-	remotePath := fmt.Sprintf("%s:%s/sync.json", remoteConfig.RemoteName, remoteConfig.BucketName)
-
 	if !localExists {
 		// Local file doesn't exist - try to pull from remote
 		fmt.Println("sync.json not found locally, attempting to pull from remote...")
@@ -94,13 +97,21 @@ func (cs *ConfigService) LoadSelectedProjectConfig() (ProjectConfig, error) {
 
 		// Truncate to second precision since remote timestamps don't have sub-second precision
 		localModTime := localFileInfo.ModTime().Truncate(time.Second)
-		remoteModTime, remoteErr := cs.getRemoteFileModTime(remotePath)
+		remoteModTime, remoteErr := cs.getRemoteFileModTime(remoteConfig.remoteRoot(), "sync.json")
 
 		shouldPullFromRemote := false
 		if remoteErr != nil {
 			// Couldn't get remote mod time (offline, file doesn't exist remotely, etc.)
 			// Fall back to using local file
 			fmt.Printf("Could not check remote sync.json (%v), using local copy\n", remoteErr)
+		} else if cs.configManager.configOutbox.has(selectedProject) {
+			// Local changes are queued for upload, so never pull over them. The remote is reachable
+			// now, so retry the upload right away; if the remote changed since the changes were
+			// queued, the upload is held back and the conflict reported until the user picks one.
+			fmt.Println("Local sync.json has a queued upload, retrying it now...")
+			if pushErr := cs.configManager.pushProjectConfig(selectedProject); pushErr != nil {
+				fmt.Printf("Queued sync.json upload was not sent (%v)\n", pushErr)
+			}
 		} else if remoteModTime.After(localModTime) {
 			// Remote is newer - pull it
 			fmt.Printf("Remote sync.json is newer (remote: %v, local: %v), pulling from remote...\n",
@@ -108,6 +119,7 @@ func (cs *ConfigService) LoadSelectedProjectConfig() (ProjectConfig, error) {
 			shouldPullFromRemote = true
 		} else if localModTime.After(remoteModTime) {
 			// Local is newer than remote - this might indicate un-synced changes
+			cs.configManager.recordKnownRemoteSyncFile(selectedProject, remoteConfig)
 			fmt.Printf("Local sync.json is newer than remote (local: %v, remote: %v)\n",
 				localModTime.Format(time.RFC3339), remoteModTime.Format(time.RFC3339))
 
@@ -121,6 +133,7 @@ func (cs *ConfigService) LoadSelectedProjectConfig() (ProjectConfig, error) {
 			})
 		} else {
 			// Local and remote are the same
+			cs.configManager.recordKnownRemoteSyncFile(selectedProject, remoteConfig)
 			fmt.Printf("Local sync.json matches remote (both: %v)\n", localModTime.Format(time.RFC3339))
 		}
 
@@ -153,38 +166,247 @@ func (cs *ConfigService) LoadSelectedProjectConfig() (ProjectConfig, error) {
 		fmt.Println("Loaded sync.json from:", configFile)
 		cs.configManager.SetProjectConfig(loadedConfig)
 	}
+
+	// Reject folder entries whose paths could reach outside the project. The config stays loaded so
+	// later saves keep the entries, but every operation on them is refused until they are fixed.
+	if pathErr := validateProjectFolderPaths(cs.configManager.GetProjectConfig()); pathErr != nil && err == nil {
+		err = pathErr
+	}
+
+	// Warn about local folders that are never backed up because nobody registered them
+	cs.configManager.warnUntrackedLocalFolders()
+
 	return *cs.configManager.GetProjectConfig(), err
 }
 
 // Load the global configuration. This configuration determines what the Rclone remotes
-// are, and where their corresponding local project folders are found. Note that we return
-// the entire configuration object, in addition to the selected project string. The frontend
-// doesn't need the entire global configuration, so the selected project name should be
-// enough to allow user selection.
-// TODO Don't expose the application keys in the frontend; send only the remote names.
-func (cs *ConfigService) LoadGlobalConfig() (GlobalConfig, string, error) {
+// are, and where their corresponding local project folders are found. Only the redacted
+// view is returned, so no credentials or credential references ever reach the frontend.
+func (cs *ConfigService) LoadGlobalConfig() (GlobalConfigView, error) {
 	// Retrieve the default config file path and ensure it exists.
 	configFilePath, err := cs.configManager.getDefaultConfigPath()
 	if err != nil {
-		return GlobalConfig{}, "", fmt.Errorf("failed to get or create default config path: %v", err)
+		return GlobalConfigView{}, fmt.Errorf("failed to get or create default config path: %v", err)
 	}
 
 	// Load the existing configuration file.
 	loadedConfig, loadErr := loadConfig[GlobalConfig](configFilePath)
 	if loadErr != nil {
-		return GlobalConfig{}, "", fmt.Errorf("failed to load global configuration: %v", loadErr)
+		return GlobalConfigView{}, fmt.Errorf("failed to load global configuration: %v", loadErr)
+	}
+
+	// Open the credential store and move any plaintext secrets from older config files into it.
+	credentialStore, storeErr := newCredentialStore(loadedConfig)
+	if storeErr != nil {
+		return GlobalConfigView{}, fmt.Errorf("failed to open credential store: %v", storeErr)
+	}
+	if migrateErr := migrateLegacySecrets(configFilePath, loadedConfig, credentialStore); migrateErr != nil {
+		return GlobalConfigView{}, fmt.Errorf("failed to migrate secrets to the credential store: %v", migrateErr)
 	}
 
 	// Perform Rclone-specific actions on the configuration.
-	if rcloneConfigErr := handleRcloneConfig(loadedConfig); rcloneConfigErr != nil {
-		return GlobalConfig{}, "", fmt.Errorf("failed to handle Rclone-specific configuration: %v", rcloneConfigErr)
+	if rcloneConfigErr := handleRcloneConfig(loadedConfig, credentialStore); rcloneConfigErr != nil {
+		return GlobalConfigView{}, fmt.Errorf("failed to handle Rclone-specific configuration: %v", rcloneConfigErr)
 	}
 
 	// Update the configuration manager with the loaded configuration.
 	cs.configManager.SetGlobalConfig(loadedConfig)
+	cs.configManager.SetCredentialStore(credentialStore)
+
+	// Apply the transfer profile, and the bandwidth limit following its schedule
+	cs.configManager.applyTransferProfile()
+	cs.configManager.startBandwidthScheduler()
+
+	// Resume retrying any sync.json uploads that were still queued when the app was last closed.
+	// This comes last, so the uploads run with the remotes and credentials in place.
+	cs.configManager.startConfigSyncRetries()
+
+	// Return the redacted view of the loaded configuration.
+	return loadedConfig.View(), nil
+}
+
+// AddProject adds a new project to the global configuration. The account and key are saved to
+// the credential store; only a reference to them is kept in the configuration.
+func (cs *ConfigService) AddProject(projectName string, settings ProjectSettings, account string, key string) (GlobalConfigView, error) {
+	return cs.updateGlobalConfig(func(globalConfig *GlobalConfig, credentialStore CredentialStore) error {
+		if projectName == "" {
+			return errors.New("a project name must be specified")
+		}
+		if _, exists := globalConfig.Remotes[projectName]; exists {
+			return fmt.Errorf("a project with the name '%s' already exists", projectName)
+		}
+		if err := validateProjectSettings(globalConfig, projectName, settings); err != nil {
+			return err
+		}
+
+		remote := applyProjectSettings(RemoteConfig{}, settings)
+		ref, err := newCredentialRef(globalConfig, projectName, credentialKindRemote)
+		if err != nil {
+			return err
+		}
+		remote.CredentialRef = ref
+		if err := saveRemoteCredentials(credentialStore, remote.CredentialRef, RemoteCredentials{Account: account, Key: key}); err != nil {
+			return err
+		}
+
+		if globalConfig.Remotes == nil {
+			globalConfig.Remotes = make(map[string]RemoteConfig)
+		}
+		globalConfig.Remotes[projectName] = remote
+		return nil
+	})
+}
+
+// UpdateProject changes the non-secret settings of an existing project.
+func (cs *ConfigService) UpdateProject(projectName string, settings ProjectSettings) (GlobalConfigView, error) {
+	return cs.updateGlobalConfig(func(globalConfig *GlobalConfig, credentialStore CredentialStore) error {
+		remote, exists := globalConfig.Remotes[projectName]
+		if !exists {
+			return fmt.Errorf("project '%s' does not exist", projectName)
+		}
+		if err := validateProjectSettings(globalConfig, projectName, settings); err != nil {
+			return err
+		}
+		globalConfig.Remotes[projectName] = applyProjectSettings(remote, settings)
+		return nil
+	})
+}
+
+// SetProjectCredentials replaces the account and key of an existing project in the credential store.
+func (cs *ConfigService) SetProjectCredentials(projectName string, account string, key string) (GlobalConfigView, error) {
+	return cs.updateGlobalConfig(func(globalConfig *GlobalConfig, credentialStore CredentialStore) error {
+		remote, exists := globalConfig.Remotes[projectName]
+		if !exists {
+			return fmt.Errorf("project '%s' does not exist", projectName)
+		}
+		if remote.CredentialRef == "" {
+			ref, err := newCredentialRef(globalConfig, projectName, credentialKindRemote)
+			if err != nil {
+				return err
+			}
+			remote.CredentialRef = ref
+			globalConfig.Remotes[projectName] = remote
+		} else if owner := credentialRefOwner(globalConfig, remote.CredentialRef, projectName); owner != "" {
+			return fmt.Errorf("the credentials of project '%s' are shared with project '%s'; remove and re-add one of them first", projectName, owner)
+		}
+		return saveRemoteCredentials(credentialStore, remote.CredentialRef, RemoteCredentials{Account: account, Key: key})
+	})
+}
+
+// SetProjectEncryption turns client-side encryption on or off for a project. When enabled, the
+// bucket is wrapped in an rclone crypt remote using the given password and optional salt, which
+// are saved to the credential store. Existing unencrypted data in the bucket is not converted
+// and won't be visible through the crypt remote.
+func (cs *ConfigService) SetProjectEncryption(projectName string, enabled bool, password string, password2 string) (GlobalConfigView, error) {
+	return cs.updateGlobalConfig(func(globalConfig *GlobalConfig, credentialStore CredentialStore) error {
+		remote, exists := globalConfig.Remotes[projectName]
+		if !exists {
+			return fmt.Errorf("project '%s' does not exist", projectName)
+		}
+		if enabled {
+			if password == "" {
+				return errors.New("a crypt password must be specified")
+			}
+			if remote.CryptRef == "" {
+				ref, err := newCredentialRef(globalConfig, projectName, credentialKindCrypt)
+				if err != nil {
+					return err
+				}
+				remote.CryptRef = ref
+			} else if owner := credentialRefOwner(globalConfig, remote.CryptRef, projectName); owner != "" {
+				return fmt.Errorf("the crypt passwords of project '%s' are shared with project '%s'; remove and re-add one of them first", projectName, owner)
+			}
+			if err := saveCryptCredentials(credentialStore, remote.CryptRef, CryptCredentials{Password: password, Password2: password2}); err != nil {
+				return err
+			}
+		}
+		remote.Encrypted = enabled
+		globalConfig.Remotes[projectName] = remote
+		return nil
+	})
+}
+
+// RemoveProject removes a project and its credentials from the global configuration. The local
+// project folder and the remote bucket are left untouched.
+func (cs *ConfigService) RemoveProject(projectName string) (GlobalConfigView, error) {
+	return cs.updateGlobalConfig(func(globalConfig *GlobalConfig, credentialStore CredentialStore) error {
+		remote, exists := globalConfig.Remotes[projectName]
+		if !exists {
+			return fmt.Errorf("project '%s' does not exist", projectName)
+		}
+		for _, ref := range []string{remote.CredentialRef, remote.CryptRef} {
+			// Leave secrets that another project still uses, which older versions could set up
+			if ref == "" || credentialRefOwner(globalConfig, ref, projectName) != "" {
+				continue
+			}
+			if err := credentialStore.Delete(ref); err != nil && !errors.Is(err, ErrCredentialNotFound) {
+				return err
+			}
+		}
+		delete(globalConfig.Remotes, projectName)
+		if globalConfig.SelectedProject == projectName {
+			globalConfig.SelectedProject = ""
+		}
+		return nil
+	})
+}
+
+// updateGlobalConfig applies a mutation to a copy of the global config. Only if the mutation
+// succeeds is the copy written to disk, and only once it's on disk is it set on the config
+// manager and turned into a new rclone config. If either step fails, the secrets the mutation
+// changed in the credential store are restored.
+func (cs *ConfigService) updateGlobalConfig(mutate func(globalConfig *GlobalConfig, credentialStore CredentialStore) error) (GlobalConfigView, error) {
+	credentialStore := cs.configManager.GetCredentialStore()
+	if credentialStore == nil {
+		return GlobalConfigView{}, errors.New("the global configuration has not been loaded")
+	}
 
-	// Return the loaded configuration and the selected project.
-	return *loadedConfig, loadedConfig.SelectedProject, nil
+	journal := newCredentialJournal(credentialStore)
+	updatedConfig, err := cs.configManager.UpdateGlobalConfig(func(globalConfig *GlobalConfig) error {
+		if err := mutate(globalConfig, journal); err != nil {
+			return err
+		}
+		return cs.configManager.writeGlobalConfig(globalConfig)
+	})
+	if err != nil {
+		journal.rollback()
+		return cs.configManager.GetGlobalConfig().View(), err
+	}
+
+	if err := handleRcloneConfig(updatedConfig, credentialStore); err != nil {
+		return updatedConfig.View(), fmt.Errorf("failed to handle Rclone-specific configuration: %v", err)
+	}
+
+	return updatedConfig.View(), nil
+}
+
+// validateProjectSettings checks the settings of a new or updated project.
+func validateProjectSettings(globalConfig *GlobalConfig, projectName string, settings ProjectSettings) error {
+	if settings.RemoteName == "" {
+		return errors.New("a remote name must be specified")
+	}
+	if settings.Type == "" {
+		return errors.New("a remote type must be specified")
+	}
+	if settings.LocalPath == "" {
+		return errors.New("a local path must be specified")
+	}
+	for otherProject, remote := range globalConfig.Remotes {
+		if otherProject != projectName && remote.RemoteName == settings.RemoteName {
+			return fmt.Errorf("remote name '%s' is already used by project '%s'", settings.RemoteName, otherProject)
+		}
+	}
+	return nil
+}
+
+// applyProjectSettings copies the non-secret settings onto a remote config.
+func applyProjectSettings(remote RemoteConfig, settings ProjectSettings) RemoteConfig {
+	remote.RemoteName = settings.RemoteName
+	remote.BucketName = settings.BucketName
+	remote.Type = settings.Type
+	remote.LocalPath = settings.LocalPath
+	remote.FullBackupPath = settings.FullBackupPath
+	return remote
 }
 
 // Generic loadConfig function
@@ -211,34 +433,174 @@ func loadConfig[T any](path string) (*T, error) {
 	return &config, nil
 }
 
-// handleRcloneConfig handles the Rclone configuration logic for GlobalConfig
-func handleRcloneConfig(globalConfig *GlobalConfig) error {
-	var rcloneConfig string
+// rcloneRemote is a remote in the embedded rclone's config.
+type rcloneRemote struct {
+	Name       string
+	Type       string
+	Parameters map[string]string // Secrets in the clear; rclone obscures the password fields itself
+}
+
+// handleRcloneConfig handles the Rclone configuration logic for GlobalConfig. The remotes are
+// created with config/create in rclone's in-memory config, with the secrets read from the
+// credential store, so no credentials are ever written to an rclone.conf. Remotes of removed
+// projects are deleted, and the user's own rclone.conf (and any remotes they set up by hand) is
+// never touched.
+func handleRcloneConfig(globalConfig *GlobalConfig, credentialStore CredentialStore) error {
+	remotes := buildRcloneRemotes(globalConfig, credentialStore)
+
+	wanted := make(map[string]bool, len(remotes))
+	for _, remote := range remotes {
+		wanted[remote.Name] = true
+	}
+	existing, err := RcloneListRemotes()
+	if err != nil {
+		return fmt.Errorf("failed to list rclone remotes: %v", err)
+	}
+	for _, name := range existing {
+		if !wanted[name] {
+			if err := RcloneDeleteRemote(name); err != nil {
+				return fmt.Errorf("failed to delete rclone remote '%s': %v", name, err)
+			}
+		}
+	}
+	for _, remote := range remotes {
+		if err := RcloneCreateRemote(remote.Name, remote.Type, remote.Parameters); err != nil {
+			return fmt.Errorf("failed to create rclone remote '%s': %v", remote.Name, err)
+		}
+	}
+
+	// Drop the remotes rclone has cached, so changed credentials and options are used right away
+	if err := RcloneClearFsCache(); err != nil {
+		return fmt.Errorf("failed to clear rclone's remote cache: %v", err)
+	}
+
+	removeLegacyRcloneConfig()
+	return nil
+}
+
+// buildRcloneRemotes returns the rclone remotes of every project: its bucket's remote and, for
+// encrypted projects, the crypt remote wrapping it. A project whose secrets can't be loaded is
+// configured without them, so its transfers fail with an authentication error.
+func buildRcloneRemotes(globalConfig *GlobalConfig, credentialStore CredentialStore) []rcloneRemote {
+	var remotes []rcloneRemote
 	for _, remote := range globalConfig.Remotes {
-		rcloneConfig += fmt.Sprintf(
-			"[%s]\ntype = %s\naccount = %s\nkey = %s\n\n",
-			remote.RemoteName, remote.Type, remote.Account, remote.Key,
-		)
+		var credentials RemoteCredentials
+		if remote.CredentialRef == "" {
+			fmt.Printf("Warning: remote '%s' has no credential reference\n", remote.RemoteName)
+		} else if loaded, err := loadRemoteCredentials(credentialStore, remote.CredentialRef); err != nil {
+			fmt.Printf("Warning: failed to load credentials for remote '%s': %v\n", remote.RemoteName, err)
+		} else {
+			credentials = loaded
+		}
+		parameters := map[string]string{
+			"account": credentials.Account,
+			"key":     credentials.Key,
+		}
+		// The upload chunk size is a backend option, so it can't be passed with the RPC calls. Only
+		// some backends have it; on the others it would do nothing.
+		if chunkSize := remote.TransferProfile.UploadChunkSize; chunkSize != "" {
+			if supportsUploadChunkSize(remote.Type) {
+				parameters["chunk_size"] = chunkSize
+			} else {
+				fmt.Printf("Warning: remote '%s' of type %s doesn't support an upload chunk size; ignoring it\n", remote.RemoteName, remote.Type)
+			}
+		}
+		remotes = append(remotes, rcloneRemote{Name: remote.RemoteName, Type: remote.Type, Parameters: parameters})
+
+		if remote.Encrypted {
+			cryptRemote, err := buildCryptRemote(remote, credentialStore)
+			if err != nil {
+				fmt.Printf("Warning: failed to configure encryption for remote '%s': %v\n", remote.RemoteName, err)
+				continue
+			}
+			remotes = append(remotes, cryptRemote)
+		}
 	}
+	sort.Slice(remotes, func(i, j int) bool {
+		return remotes[i].Name < remotes[j].Name
+	})
+	return remotes
+}
 
-	// Get the default Rclone config directory
-	rcloneConfigPath, err := getDefaultRcloneConfigPath()
+// buildCryptRemote builds the crypt remote wrapping an encrypted project's bucket. The passwords
+// come from the credential store.
+func buildCryptRemote(remote RemoteConfig, credentialStore CredentialStore) (rcloneRemote, error) {
+	if remote.CryptRef == "" {
+		return rcloneRemote{}, errors.New("no crypt password reference is configured")
+	}
+	cryptCredentials, err := loadCryptCredentials(credentialStore, remote.CryptRef)
 	if err != nil {
-		return fmt.Errorf("failed to get default rclone config path: %v", err)
+		return rcloneRemote{}, err
+	}
+	if cryptCredentials.Password == "" {
+		return rcloneRemote{}, errors.New("the crypt password is empty")
 	}
 
-	// Write the Rclone config to the file
-	if err := os.WriteFile(rcloneConfigPath, []byte(rcloneConfig), 0600); err != nil {
-		return fmt.Errorf("failed to write rclone config: %v", err)
+	parameters := map[string]string{
+		"remote":   fmt.Sprintf("%s:%s", remote.RemoteName, remote.BucketName),
+		"password": cryptCredentials.Password,
 	}
+	if cryptCredentials.Password2 != "" {
+		parameters["password2"] = cryptCredentials.Password2
+	}
+	return rcloneRemote{Name: remote.cryptRemoteName(), Type: "crypt", Parameters: parameters}, nil
+}
 
-	fmt.Printf("Rclone config written to: %s\n", rcloneConfigPath)
-	return nil
+// removeLegacyRcloneConfig deletes the app-private rclone.conf that earlier versions wrote, as
+// it holds the account keys in plaintext.
+func removeLegacyRcloneConfig() {
+	path, err := getLegacyRcloneConfigPath()
+	if err != nil {
+		return
+	}
+	if err := os.Remove(path); err == nil {
+		fmt.Printf("Removed legacy rclone config with plaintext secrets: %s\n", path)
+	} else if !os.IsNotExist(err) {
+		fmt.Printf("Warning: Failed to remove legacy rclone config '%s': %v\n", path, err)
+	}
 }
 
-// getDefaultRcloneConfigPath gets the default Rclone config file path via librclone RPC.
-func getDefaultRcloneConfigPath() (string, error) {
-	return RcloneGetConfigPath()
+// migrateLegacySecrets moves plaintext account/key values left in config.json by older versions
+// into the credential store, records a reference in their place, and rewrites config.json
+// without the secrets.
+func migrateLegacySecrets(configFilePath string, globalConfig *GlobalConfig, credentialStore CredentialStore) error {
+	legacyConfig, err := loadConfig[legacyGlobalSecrets](configFilePath)
+	if err != nil {
+		return err
+	}
+
+	migrated := false
+	for project, secrets := range legacyConfig.Remotes {
+		if secrets.Account == "" && secrets.Key == "" {
+			continue
+		}
+		remote, exists := globalConfig.Remotes[project]
+		if !exists {
+			continue
+		}
+		if remote.CredentialRef == "" {
+			ref, err := newCredentialRef(globalConfig, project, credentialKindRemote)
+			if err != nil {
+				return err
+			}
+			remote.CredentialRef = ref
+		}
+		credentials := RemoteCredentials{Account: secrets.Account, Key: secrets.Key}
+		if err := saveRemoteCredentials(credentialStore, remote.CredentialRef, credentials); err != nil {
+			return err
+		}
+		globalConfig.Remotes[project] = remote
+		migrated = true
+	}
+
+	if !migrated {
+		return nil
+	}
+	if err := saveConfig(configFilePath, globalConfig); err != nil {
+		return fmt.Errorf("failed to rewrite config without secrets: %v", err)
+	}
+	fmt.Println("Moved remote secrets from config.json into the credential store")
+	return nil
 }
 
 // pullSyncFileFromRemote pulls the sync.json file from the remote to the local project path.
@@ -249,24 +611,35 @@ func (cs *ConfigService) pullSyncFileFromRemote() error {
 		return errors.New("no project selected")
 	}
 
-	remoteConfig := cs.configManager.globalConfig.Remotes[selectedProject]
+	remoteConfig := cs.configManager.GetGlobalConfig().Remotes[selectedProject]
 	projectPath := remoteConfig.LocalPath
 	configFile := filepath.Join(projectPath, "sync.json")
-	srcFs := fmt.Sprintf("%s:%s", remoteConfig.RemoteName, remoteConfig.BucketName)
+	srcFs := remoteConfig.remoteRoot()
 
 	// Use librclone RPC to copy the single file
-	if err := RcloneCopyFile(srcFs, "sync.json", projectPath, "sync.json"); err != nil {
+	cs.configManager.configUploadMu.Lock()
+	err := RcloneCopyFile(srcFs, "sync.json", projectPath, "sync.json")
+	if err == nil {
+		cs.configManager.recordKnownRemoteSyncFile(selectedProject, remoteConfig)
+	}
+	cs.configManager.configUploadMu.Unlock()
+	if err != nil {
 		return fmt.Errorf("rclone copyfile failed: %v", err)
 	}
 
+	// The local sync.json now matches the remote, so any queued upload has nothing left to send
+	if cs.configManager.configOutbox.remove(selectedProject) {
+		cs.configManager.emitPendingConfigSync()
+	}
+
 	fmt.Printf("Successfully pulled sync.json from %s to %s\n", srcFs+"/sync.json", configFile)
 	return nil
 }
 
 // getRemoteFileModTime gets the modification time of a file on the remote using librclone RPC.
 // Returns the mod time or an error if the file doesn't exist or the call fails.
-func (cs *ConfigService) getRemoteFileModTime(remotePath string) (time.Time, error) {
-	return RcloneGetRemoteFileModTime(remotePath)
+func (cs *ConfigService) getRemoteFileModTime(fsPath string, remote string) (time.Time, error) {
+	return RcloneGetRemoteFileModTime(fsPath, remote)
 }
 
 // RefreshSyncFile manually refreshes the sync.json from the remote, overwriting the local copy.
@@ -286,7 +659,7 @@ func (cs *ConfigService) RefreshSyncFile() (ProjectConfig, error) {
 	}
 
 	// Reload the config from disk
-	remoteConfig := cs.configManager.globalConfig.Remotes[selectedProject]
+	remoteConfig := cs.configManager.GetGlobalConfig().Remotes[selectedProject]
 	projectPath := remoteConfig.LocalPath
 	configFile := filepath.Join(projectPath, "sync.json")
 
@@ -307,5 +680,10 @@ func (cs *ConfigService) RefreshSyncFile() (ProjectConfig, error) {
 	cs.configManager.SetProjectConfig(loadedConfig)
 	fmt.Println("sync.json refreshed successfully")
 
+	// Reject folder entries whose paths could reach outside the project
+	if err := validateProjectFolderPaths(loadedConfig); err != nil {
+		return *loadedConfig, err
+	}
+
 	return *loadedConfig, nil
 }
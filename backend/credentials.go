@@ -0,0 +1,390 @@
+package backend
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/zalando/go-keyring"
+	"golang.org/x/crypto/scrypt"
+)
+
+// Supported values for GlobalConfig.CredentialStore.
+const (
+	CredentialStoreKeyring = "keyring"
+	CredentialStoreFile    = "file"
+)
+
+// credentialServiceName is the service name secrets are filed under in the OS keyring.
+const credentialServiceName = "rclone-selective-sync"
+
+// credentialPassphraseEnv names the environment variable holding the passphrase for the
+// encrypted file store.
+const credentialPassphraseEnv = "RCLONE_SELECTIVE_SYNC_PASSPHRASE"
+
+// ErrCredentialNotFound is returned when no secret is stored under the requested reference.
+var ErrCredentialNotFound = errors.New("credential not found")
+
+// CredentialStore keeps secrets out of config.json. Each secret is addressed by a reference
+// string, which is what gets persisted in the configuration instead of the secret itself.
+type CredentialStore interface {
+	Get(ref string) (string, error)
+	Set(ref string, secret string) error
+	Delete(ref string) error
+}
+
+// RemoteCredentials are the secrets needed to reach a remote. They are stored as a single
+// JSON-encoded secret under RemoteConfig.CredentialRef.
+type RemoteCredentials struct {
+	Account string `json:"account"`
+	Key     string `json:"key"`
+}
+
+// CryptCredentials are the passwords of an encrypted project's crypt remote. Password2 is the
+// optional salt. They are stored as a single JSON-encoded secret under RemoteConfig.CryptRef.
+type CryptCredentials struct {
+	Password  string `json:"password"`
+	Password2 string `json:"password2"`
+}
+
+// newCredentialStore returns the credential store selected in the global config. The OS keyring
+// is the default; the encrypted file store needs a passphrase in RCLONE_SELECTIVE_SYNC_PASSPHRASE.
+func newCredentialStore(globalConfig *GlobalConfig) (CredentialStore, error) {
+	switch globalConfig.CredentialStore {
+	case "", CredentialStoreKeyring:
+		return NewKeyringCredentialStore(), nil
+	case CredentialStoreFile:
+		passphrase := os.Getenv(credentialPassphraseEnv)
+		if passphrase == "" {
+			return nil, fmt.Errorf("the file credential store requires %s to be set", credentialPassphraseEnv)
+		}
+		configDir, err := getAppConfigDir()
+		if err != nil {
+			return nil, err
+		}
+		return NewFileCredentialStore(filepath.Join(configDir, "credentials.enc"), passphrase), nil
+	default:
+		return nil, fmt.Errorf("unknown credential store '%s'", globalConfig.CredentialStore)
+	}
+}
+
+// Kinds of secrets a project keeps in the credential store.
+const (
+	credentialKindRemote = "remote" // RemoteCredentials, under RemoteConfig.CredentialRef
+	credentialKindCrypt  = "crypt"  // CryptCredentials, under RemoteConfig.CryptRef
+)
+
+// newCredentialRef returns the reference for a project's secret of the given kind. It is based on
+// the project name, which never changes, rather than the remote name, which can be renamed and
+// then reused by another project. Returns an error if another project already uses the reference,
+// so one project can never overwrite or delete the secrets of another.
+func newCredentialRef(globalConfig *GlobalConfig, projectName string, kind string) (string, error) {
+	ref := fmt.Sprintf("project:%s:%s", projectName, kind)
+	if owner := credentialRefOwner(globalConfig, ref, projectName); owner != "" {
+		return "", fmt.Errorf("the credential reference '%s' is already used by project '%s'", ref, owner)
+	}
+	return ref, nil
+}
+
+// credentialRefOwner returns the project other than the given one that uses the reference, or an
+// empty string if there is none.
+func credentialRefOwner(globalConfig *GlobalConfig, ref string, projectName string) string {
+	for project, remote := range globalConfig.Remotes {
+		if project != projectName && (remote.CredentialRef == ref || remote.CryptRef == ref) {
+			return project
+		}
+	}
+	return ""
+}
+
+// loadRemoteCredentials reads and decodes the credentials stored under the given reference.
+func loadRemoteCredentials(store CredentialStore, ref string) (RemoteCredentials, error) {
+	var credentials RemoteCredentials
+	secret, err := store.Get(ref)
+	if err != nil {
+		return credentials, err
+	}
+	if err := json.Unmarshal([]byte(secret), &credentials); err != nil {
+		return credentials, fmt.Errorf("failed to decode credentials '%s': %v", ref, err)
+	}
+	return credentials, nil
+}
+
+// saveRemoteCredentials encodes and stores the credentials under the given reference.
+func saveRemoteCredentials(store CredentialStore, ref string, credentials RemoteCredentials) error {
+	secret, err := json.Marshal(credentials)
+	if err != nil {
+		return fmt.Errorf("failed to encode credentials '%s': %v", ref, err)
+	}
+	return store.Set(ref, string(secret))
+}
+
+// loadCryptCredentials reads and decodes the crypt passwords stored under the given reference.
+func loadCryptCredentials(store CredentialStore, ref string) (CryptCredentials, error) {
+	var credentials CryptCredentials
+	secret, err := store.Get(ref)
+	if err != nil {
+		return credentials, err
+	}
+	if err := json.Unmarshal([]byte(secret), &credentials); err != nil {
+		return credentials, fmt.Errorf("failed to decode crypt passwords '%s': %v", ref, err)
+	}
+	return credentials, nil
+}
+
+// saveCryptCredentials encodes and stores the crypt passwords under the given reference.
+func saveCryptCredentials(store CredentialStore, ref string, credentials CryptCredentials) error {
+	secret, err := json.Marshal(credentials)
+	if err != nil {
+		return fmt.Errorf("failed to encode crypt passwords '%s': %v", ref, err)
+	}
+	return store.Set(ref, string(secret))
+}
+
+// credentialJournal wraps a credential store and remembers each secret's value before its first
+// change, so the changes of a failed config update can be undone.
+type credentialJournal struct {
+	store    CredentialStore
+	previous map[string]*string // nil for a secret that didn't exist
+}
+
+func newCredentialJournal(store CredentialStore) *credentialJournal {
+	return &credentialJournal{store: store, previous: make(map[string]*string)}
+}
+
+func (cj *credentialJournal) Get(ref string) (string, error) {
+	return cj.store.Get(ref)
+}
+
+func (cj *credentialJournal) Set(ref string, secret string) error {
+	if err := cj.remember(ref); err != nil {
+		return err
+	}
+	return cj.store.Set(ref, secret)
+}
+
+func (cj *credentialJournal) Delete(ref string) error {
+	if err := cj.remember(ref); err != nil {
+		return err
+	}
+	return cj.store.Delete(ref)
+}
+
+// remember records the secret's current value, unless it was already recorded.
+func (cj *credentialJournal) remember(ref string) error {
+	if _, recorded := cj.previous[ref]; recorded {
+		return nil
+	}
+	secret, err := cj.store.Get(ref)
+	if errors.Is(err, ErrCredentialNotFound) {
+		cj.previous[ref] = nil
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read credential '%s' before changing it: %v", ref, err)
+	}
+	cj.previous[ref] = &secret
+	return nil
+}
+
+// rollback puts every changed secret back to its recorded value.
+func (cj *credentialJournal) rollback() {
+	for ref, secret := range cj.previous {
+		var err error
+		if secret == nil {
+			if err = cj.store.Delete(ref); errors.Is(err, ErrCredentialNotFound) {
+				err = nil
+			}
+		} else {
+			err = cj.store.Set(ref, *secret)
+		}
+		if err != nil {
+			fmt.Printf("Warning: Failed to restore credential '%s': %v\n", ref, err)
+		}
+	}
+	cj.previous = make(map[string]*string)
+}
+
+// ==================== OS Keyring ====================
+
+// KeyringCredentialStore stores secrets in the OS keyring (Windows Credential Manager,
+// macOS Keychain, or the Secret Service on Linux).
+type KeyringCredentialStore struct{}
+
+func NewKeyringCredentialStore() *KeyringCredentialStore {
+	return &KeyringCredentialStore{}
+}
+
+func (ks *KeyringCredentialStore) Get(ref string) (string, error) {
+	secret, err := keyring.Get(credentialServiceName, ref)
+	if errors.Is(err, keyring.ErrNotFound) {
+		return "", ErrCredentialNotFound
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to read '%s' from the OS keyring: %v", ref, err)
+	}
+	return secret, nil
+}
+
+func (ks *KeyringCredentialStore) Set(ref string, secret string) error {
+	if err := keyring.Set(credentialServiceName, ref, secret); err != nil {
+		return fmt.Errorf("failed to write '%s' to the OS keyring: %v", ref, err)
+	}
+	return nil
+}
+
+func (ks *KeyringCredentialStore) Delete(ref string) error {
+	err := keyring.Delete(credentialServiceName, ref)
+	if errors.Is(err, keyring.ErrNotFound) {
+		return ErrCredentialNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to delete '%s' from the OS keyring: %v", ref, err)
+	}
+	return nil
+}
+
+// ==================== Encrypted File ====================
+
+// FileCredentialStore stores secrets in a single file encrypted with AES-256-GCM, using a key
+// derived from a passphrase with scrypt. It needs no OS services, so it also works headless.
+type FileCredentialStore struct {
+	path       string
+	passphrase string
+	mu         sync.Mutex
+}
+
+// encryptedCredentialFile is the on-disk layout of the encrypted credential file.
+type encryptedCredentialFile struct {
+	Salt       []byte `json:"salt"`
+	Nonce      []byte `json:"nonce"`
+	Ciphertext []byte `json:"ciphertext"`
+}
+
+func NewFileCredentialStore(path string, passphrase string) *FileCredentialStore {
+	return &FileCredentialStore{path: path, passphrase: passphrase}
+}
+
+func (fcs *FileCredentialStore) Get(ref string) (string, error) {
+	fcs.mu.Lock()
+	defer fcs.mu.Unlock()
+
+	secrets, err := fcs.load()
+	if err != nil {
+		return "", err
+	}
+	secret, exists := secrets[ref]
+	if !exists {
+		return "", ErrCredentialNotFound
+	}
+	return secret, nil
+}
+
+func (fcs *FileCredentialStore) Set(ref string, secret string) error {
+	fcs.mu.Lock()
+	defer fcs.mu.Unlock()
+
+	secrets, err := fcs.load()
+	if err != nil {
+		return err
+	}
+	secrets[ref] = secret
+	return fcs.save(secrets)
+}
+
+func (fcs *FileCredentialStore) Delete(ref string) error {
+	fcs.mu.Lock()
+	defer fcs.mu.Unlock()
+
+	secrets, err := fcs.load()
+	if err != nil {
+		return err
+	}
+	if _, exists := secrets[ref]; !exists {
+		return ErrCredentialNotFound
+	}
+	delete(secrets, ref)
+	return fcs.save(secrets)
+}
+
+// load decrypts the credential file. A missing file is treated as an empty store.
+func (fcs *FileCredentialStore) load() (map[string]string, error) {
+	secrets := make(map[string]string)
+	data, err := os.ReadFile(fcs.path)
+	if os.IsNotExist(err) {
+		return secrets, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read credential file: %v", err)
+	}
+
+	var encrypted encryptedCredentialFile
+	if err := json.Unmarshal(data, &encrypted); err != nil {
+		return nil, fmt.Errorf("failed to parse credential file: %v", err)
+	}
+	gcm, err := fcs.cipher(encrypted.Salt)
+	if err != nil {
+		return nil, err
+	}
+	plaintext, err := gcm.Open(nil, encrypted.Nonce, encrypted.Ciphertext, nil)
+	if err != nil {
+		return nil, errors.New("failed to decrypt credential file; the passphrase may be wrong")
+	}
+	if err := json.Unmarshal(plaintext, &secrets); err != nil {
+		return nil, fmt.Errorf("failed to decode credential file: %v", err)
+	}
+	return secrets, nil
+}
+
+// save encrypts the secrets with a fresh salt and nonce and writes them to the credential file.
+func (fcs *FileCredentialStore) save(secrets map[string]string) error {
+	plaintext, err := json.Marshal(secrets)
+	if err != nil {
+		return fmt.Errorf("failed to encode credentials: %v", err)
+	}
+
+	salt := make([]byte, 16)
+	if _, err := rand.Read(salt); err != nil {
+		return fmt.Errorf("failed to generate salt: %v", err)
+	}
+	gcm, err := fcs.cipher(salt)
+	if err != nil {
+		return err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return fmt.Errorf("failed to generate nonce: %v", err)
+	}
+
+	data, err := json.Marshal(encryptedCredentialFile{
+		Salt:       salt,
+		Nonce:      nonce,
+		Ciphertext: gcm.Seal(nil, nonce, plaintext, nil),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode credential file: %v", err)
+	}
+	if err := os.WriteFile(fcs.path, data, 0600); err != nil {
+		return fmt.Errorf("failed to write credential file: %v", err)
+	}
+	return nil
+}
+
+// cipher derives the AES-256-GCM cipher for the given salt from the passphrase.
+func (fcs *FileCredentialStore) cipher(salt []byte) (cipher.AEAD, error) {
+	key, err := scrypt.Key([]byte(fcs.passphrase), salt, 1<<15, 8, 1, 32)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive credential key: %v", err)
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,164 @@
+package backend
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestFileCredentialStoreRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "credentials.enc")
+	store := NewFileCredentialStore(path, "correct horse")
+
+	if err := store.Set("remote:one", "secret-one"); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+	if err := store.Set("remote:two", "secret-two"); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+
+	// A new store reading the same file sees both secrets
+	reopened := NewFileCredentialStore(path, "correct horse")
+	for ref, want := range map[string]string{"remote:one": "secret-one", "remote:two": "secret-two"} {
+		got, err := reopened.Get(ref)
+		if err != nil {
+			t.Fatalf("Get(%q) failed: %v", ref, err)
+		}
+		if got != want {
+			t.Errorf("Get(%q) = %q, want %q", ref, got, want)
+		}
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read credential file: %v", err)
+	}
+	if strings.Contains(string(data), "secret-one") {
+		t.Errorf("credential file contains a secret in plaintext")
+	}
+}
+
+func TestFileCredentialStoreMissing(t *testing.T) {
+	store := NewFileCredentialStore(filepath.Join(t.TempDir(), "credentials.enc"), "correct horse")
+	if _, err := store.Get("remote:none"); !errors.Is(err, ErrCredentialNotFound) {
+		t.Errorf("Get on an empty store returned %v, want ErrCredentialNotFound", err)
+	}
+}
+
+func TestFileCredentialStoreWrongPassphrase(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "credentials.enc")
+	if err := NewFileCredentialStore(path, "correct horse").Set("remote:one", "secret-one"); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+
+	wrong := NewFileCredentialStore(path, "battery staple")
+	if _, err := wrong.Get("remote:one"); err == nil || errors.Is(err, ErrCredentialNotFound) {
+		t.Errorf("Get with the wrong passphrase returned %v, want a decryption error", err)
+	}
+	// A failed decryption must not let a write replace the existing secrets
+	if err := wrong.Set("remote:two", "secret-two"); err == nil {
+		t.Errorf("Set with the wrong passphrase succeeded")
+	}
+	if got, err := NewFileCredentialStore(path, "correct horse").Get("remote:one"); err != nil || got != "secret-one" {
+		t.Errorf("Get after a failed write = %q, %v, want %q", got, err, "secret-one")
+	}
+}
+
+func TestFileCredentialStoreDelete(t *testing.T) {
+	store := NewFileCredentialStore(filepath.Join(t.TempDir(), "credentials.enc"), "correct horse")
+	if err := store.Set("remote:one", "secret-one"); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+	if err := store.Set("remote:two", "secret-two"); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+
+	if err := store.Delete("remote:one"); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	if _, err := store.Get("remote:one"); !errors.Is(err, ErrCredentialNotFound) {
+		t.Errorf("Get after Delete returned %v, want ErrCredentialNotFound", err)
+	}
+	if got, err := store.Get("remote:two"); err != nil || got != "secret-two" {
+		t.Errorf("Get of the other secret = %q, %v, want %q", got, err, "secret-two")
+	}
+	if err := store.Delete("remote:one"); !errors.Is(err, ErrCredentialNotFound) {
+		t.Errorf("second Delete returned %v, want ErrCredentialNotFound", err)
+	}
+}
+
+func TestNewCredentialRef(t *testing.T) {
+	globalConfig := &GlobalConfig{Remotes: map[string]RemoteConfig{
+		// Project A renamed its remote from x to y, keeping the reference made for x by an older version
+		"A": {RemoteName: "y", CredentialRef: "remote:x"},
+		"C": {RemoteName: "z", CredentialRef: "project:B:remote"},
+	}}
+
+	tests := []struct {
+		name    string
+		project string
+		kind    string
+		want    string
+		wantErr bool
+	}{
+		{name: "new project", project: "B2", kind: credentialKindRemote, want: "project:B2:remote"},
+		{name: "crypt passwords", project: "A", kind: credentialKindCrypt, want: "project:A:crypt"},
+		{name: "independent of the remote name", project: "x", kind: credentialKindRemote, want: "project:x:remote"},
+		{name: "reference used by another project", project: "B", kind: credentialKindRemote, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := newCredentialRef(globalConfig, tt.project, tt.kind)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("newCredentialRef() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("newCredentialRef() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUpdateGlobalConfigRestoresCredentialsOnWriteFailure(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("USERPROFILE", home)
+
+	// A directory in place of config.json makes every write of the global config fail
+	configDir, err := getAppConfigDir()
+	if err != nil {
+		t.Fatalf("getAppConfigDir failed: %v", err)
+	}
+	if err := os.Mkdir(filepath.Join(configDir, "config.json"), 0755); err != nil {
+		t.Fatalf("failed to create the config.json directory: %v", err)
+	}
+
+	store := newMemoryCredentialStore()
+	saveRemoteCredentials(store, "project:Alpha:remote", RemoteCredentials{Account: "old-account", Key: "old-key"})
+	cm := NewConfigManager(&GlobalConfig{Remotes: map[string]RemoteConfig{
+		"Alpha": {RemoteName: "alpha", BucketName: "alpha-bucket", Type: "b2", LocalPath: t.TempDir(), CredentialRef: "project:Alpha:remote"},
+	}}, nil)
+	cm.SetCredentialStore(store)
+	cs := NewConfigService(cm)
+
+	if _, err := cs.SetProjectCredentials("Alpha", "new-account", "new-key"); err == nil {
+		t.Fatalf("SetProjectCredentials succeeded despite the failed write")
+	}
+	credentials, err := loadRemoteCredentials(store, "project:Alpha:remote")
+	if err != nil || credentials.Key != "old-key" {
+		t.Errorf("credentials after the failed write = %+v, %v; want the old key", credentials, err)
+	}
+
+	settings := ProjectSettings{RemoteName: "beta", BucketName: "beta-bucket", Type: "b2", LocalPath: t.TempDir()}
+	if _, err := cs.AddProject("Beta", settings, "account", "key"); err == nil {
+		t.Fatalf("AddProject succeeded despite the failed write")
+	}
+	if _, exists := cm.GetGlobalConfig().Remotes["Beta"]; exists {
+		t.Errorf("the project was added in memory despite the failed write")
+	}
+	if len(store.secrets) != 1 {
+		t.Errorf("credential store has %d secrets after the failed write, want 1", len(store.secrets))
+	}
+}
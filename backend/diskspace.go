@@ -0,0 +1,136 @@
+package backend
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/rclone/rclone/lib/diskusage"
+)
+
+// InsufficientDiskSpaceError is returned when a pull would download more data than the volume
+// it writes to has free.
+type InsufficientDiskSpaceError struct {
+	Path           string `json:"path"`
+	RequiredBytes  int64  `json:"required_bytes"`
+	AvailableBytes int64  `json:"available_bytes"`
+}
+
+func (e *InsufficientDiskSpaceError) Error() string {
+	return fmt.Sprintf("not enough disk space on the volume holding %s: %s required, %s available",
+		e.Path, formatSize(e.RequiredBytes), formatSize(e.AvailableBytes))
+}
+
+// isPullAction reports whether the action downloads data to the local file system.
+func isPullAction(action RcloneAction) bool {
+	return action == SYNC_PULL || action == COPY_PULL
+}
+
+// pullBytesRequired returns how many bytes a pull from remotePath into localPath would download.
+// A remote path that doesn't exist needs nothing, and a local path that doesn't exist needs everything.
+func pullBytesRequired(remotePath string, localPath string) (int64, error) {
+	if _, err := os.Stat(localPath); os.IsNotExist(err) {
+		remoteFiles, err := rcloneListFiles(remotePath)
+		if errorKind(err) == ErrorKindNotFound {
+			return 0, nil
+		}
+		if err != nil {
+			return 0, err
+		}
+		var total int64
+		for _, file := range remoteFiles {
+			if !file.IsDir {
+				total += file.Size
+			}
+		}
+		return total, nil
+	}
+
+	diff, _, err := diffFiles(remotePath, localPath)
+	if errorKind(err) == ErrorKindNotFound {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return diff.ChangeBytes, nil
+}
+
+// checkDiskSpace returns an InsufficientDiskSpaceError if the volume holding the given path has
+// less than the required bytes available. Platforms that can't report disk usage are not checked.
+func checkDiskSpace(path string, requiredBytes int64) error {
+	if requiredBytes <= 0 {
+		return nil
+	}
+
+	// The target may not exist yet; measure the closest existing parent instead
+	existingPath := filepath.Clean(path)
+	for {
+		if _, err := os.Stat(existingPath); err == nil {
+			break
+		}
+		parent := filepath.Dir(existingPath)
+		if parent == existingPath {
+			break
+		}
+		existingPath = parent
+	}
+
+	info, err := diskusage.New(existingPath)
+	if errors.Is(err, diskusage.ErrUnsupported) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to check free disk space for %s: %v", path, err)
+	}
+	if uint64(requiredBytes) > info.Available {
+		return &InsufficientDiskSpaceError{Path: path, RequiredBytes: requiredBytes, AvailableBytes: int64(info.Available)}
+	}
+	return nil
+}
+
+// preflightPullDiskSpace verifies that the project volume can hold everything the given folders,
+// and any unregistered remote directories, would download. They are pulled in parallel onto the
+// same volume, so their requirements are added up and checked together.
+func (ss *SyncService) preflightPullDiskSpace(targetFolders []string, unregisteredDirs []string) error {
+	remoteConfig := ss.configManager.GetSelectedProjectRemoteConfig()
+	if remoteConfig == nil {
+		return fmt.Errorf("selected project's remote configuration is not available")
+	}
+	projectConfig := ss.configManager.GetProjectConfig()
+
+	var requiredBytes int64
+	for _, targetFolder := range targetFolders {
+		folderConfig, exists := projectConfig.Folders[targetFolder]
+		if !exists {
+			continue
+		}
+		fullLocalPath, fullRemotePath, err := resolveFolderPaths(remoteConfig, targetFolder, folderConfig)
+		if err != nil {
+			return err
+		}
+		folderBytes, err := pullBytesRequired(fullRemotePath, fullLocalPath)
+		if err != nil {
+			return fmt.Errorf("failed to compute the download size of folder '%s': %v", targetFolder, err)
+		}
+		requiredBytes += folderBytes
+	}
+	for _, dir := range unregisteredDirs {
+		dirBytes, err := pullBytesRequired(remoteConfig.remotePath(dir), filepath.Join(remoteConfig.LocalPath, filepath.FromSlash(dir)))
+		if err != nil {
+			return fmt.Errorf("failed to compute the download size of '%s': %v", dir, err)
+		}
+		requiredBytes += dirBytes
+	}
+	return checkDiskSpace(remoteConfig.LocalPath, requiredBytes)
+}
+
+// preflightBackupDiskSpace verifies that the backup volume can hold everything a full backup would download.
+func preflightBackupDiskSpace(remoteConfig RemoteConfig) error {
+	requiredBytes, err := pullBytesRequired(remoteConfig.remoteRoot(), remoteConfig.FullBackupPath)
+	if err != nil {
+		return fmt.Errorf("failed to compute the download size of the backup: %v", err)
+	}
+	return checkDiskSpace(remoteConfig.FullBackupPath, requiredBytes)
+}
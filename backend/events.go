@@ -9,14 +9,33 @@ const (
 	EventDetectFolderComplete = "detect-folder-complete"
 	EventDetectComplete       = "detect-complete"
 	EventSyncStatus           = "sync-status"
+	EventUntrackedFolders     = "untracked-folders"
+	EventConfigSyncPending    = "config-sync-pending"
+	EventConfigSyncConflict   = "config-sync-conflict"
 )
 
 // TaskFolderCompletePayload is emitted once per folder when its rclone command finishes.
 type TaskFolderCompletePayload struct {
-	TaskID        string `json:"taskId"`
-	TargetFolder  string `json:"targetFolder"`
-	CommandOutput string `json:"commandOutput"`
-	CommandError  string `json:"commandError"`
+	TaskID         string `json:"taskId"`
+	TargetFolder   string `json:"targetFolder"`
+	CommandOutput  string `json:"commandOutput"`
+	CommandError   string `json:"commandError"`
+	ErrorKind      string `json:"errorKind"`
+	RequiredBytes  int64  `json:"requiredBytes"`  // Bytes needed, when refused for lack of disk space
+	AvailableBytes int64  `json:"availableBytes"` // Bytes free on the volume, when refused for lack of disk space
+}
+
+// newTaskFolderCompletePayload returns the event payload for the result of a single folder.
+func newTaskFolderCompletePayload(taskID string, result RcloneActionOutput) TaskFolderCompletePayload {
+	return TaskFolderCompletePayload{
+		TaskID:         taskID,
+		TargetFolder:   result.TargetFolder,
+		CommandOutput:  result.CommandOutput,
+		CommandError:   result.CommandError,
+		ErrorKind:      result.ErrorKind,
+		RequiredBytes:  result.RequiredBytes,
+		AvailableBytes: result.AvailableBytes,
+	}
 }
 
 // TaskCompletePayload is emitted when all folders in a task have finished.
@@ -30,6 +49,7 @@ type DetectFolderCompletePayload struct {
 	TargetFolder string `json:"targetFolder"`
 	HasChanges   bool   `json:"hasChanges"`
 	CommandError string `json:"commandError"`
+	ErrorKind    string `json:"errorKind"`
 }
 
 // DetectCompletePayload is emitted when all change detection is done.
@@ -46,6 +66,28 @@ type SyncStatusPayload struct {
 	SelectedProject string `json:"selectedProject"`
 }
 
+// UntrackedFoldersPayload is emitted at project load when local folders that aren't covered by
+// any registered folder are found.
+type UntrackedFoldersPayload struct {
+	SelectedProject string            `json:"selectedProject"`
+	Folders         []UntrackedFolder `json:"folders"`
+}
+
+// ConfigSyncPendingPayload is emitted whenever the set of sync.json uploads waiting to be retried
+// changes. An empty list means every change has reached the remote.
+type ConfigSyncPendingPayload struct {
+	Pending []PendingConfigSync `json:"pending"`
+}
+
+// ConfigSyncConflictPayload is emitted when a queued sync.json upload is held back because the
+// remote sync.json was changed by someone else in the meantime.
+type ConfigSyncConflictPayload struct {
+	Project       string `json:"project"`
+	Message       string `json:"message"`
+	LocalModTime  string `json:"localModTime"`
+	RemoteModTime string `json:"remoteModTime"`
+}
+
 // emitEvent is a helper that safely emits a Wails event.
 func emitEvent(name string, data interface{}) {
 	if app := application.Get(); app != nil {
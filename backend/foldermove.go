@@ -0,0 +1,153 @@
+package backend
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// MoveFolder moves a registered folder to a new path relative to the project root. The local
+// directory is renamed, the remote prefix is moved server-side, and sync.json is updated. If any
+// step fails, the steps already done are undone, so the folder is never left half moved.
+func (fs *FolderService) MoveFolder(folderKey string, newPath string) (ProjectConfig, error) {
+	projectRemoteConfig, err := fs.getProjectRemoteConfig()
+	if err != nil {
+		return ProjectConfig{}, err
+	}
+	projectConfig, err := fs.getProjectConfig()
+	if err != nil {
+		return ProjectConfig{}, err
+	}
+
+	currentConfig, exists := projectConfig.Folders[folderKey]
+	if !exists {
+		return *projectConfig, fmt.Errorf("folder '%s' does not exist in the project configuration", folderKey)
+	}
+
+	// Build and validate the folder's new configuration. The path is normalized first, like in
+	// RegisterFolder, so an absolute path inside the project root is accepted.
+	movedConfig := currentConfig
+	movedConfig.LocalPath = normalizeFolderLocalPath(newPath, projectRemoteConfig.LocalPath)
+	movedConfig.RemotePath = movedConfig.LocalPath
+	if err := validateFolderPath(movedConfig.LocalPath); err != nil {
+		return *projectConfig, fmt.Errorf("invalid new path for folder '%s': %v", folderKey, err)
+	}
+	if movedConfig.LocalPath == normalizePath(currentConfig.LocalPath) && movedConfig.RemotePath == normalizePath(currentConfig.RemotePath) {
+		return *projectConfig, fmt.Errorf("folder '%s' is already at '%s'", folderKey, movedConfig.LocalPath)
+	}
+	if err := checkFolderOverlap(projectConfig.Folders, folderKey, movedConfig, folderKey); err != nil {
+		return *projectConfig, err
+	}
+
+	oldLocalPath, oldRemotePath, err := resolveFolderPaths(projectRemoteConfig, folderKey, currentConfig)
+	if err != nil {
+		return *projectConfig, err
+	}
+	newLocalPath, newRemotePath, err := resolveFolderPaths(projectRemoteConfig, folderKey, movedConfig)
+	if err != nil {
+		return *projectConfig, err
+	}
+
+	// Refuse to merge into data that already exists at the destination
+	if _, err := os.Stat(newLocalPath); err == nil {
+		return *projectConfig, fmt.Errorf("local path '%s' already exists", newLocalPath)
+	}
+	remoteFiles, err := remoteFileCount(newRemotePath)
+	if err != nil {
+		return *projectConfig, fmt.Errorf("failed to check the remote destination: %v", err)
+	}
+	if remoteFiles > 0 {
+		return *projectConfig, fmt.Errorf("remote path '%s' already contains %d file(s)", newRemotePath, remoteFiles)
+	}
+
+	// Step 1: Move the local directory, if the folder is checked out
+	movedLocal := false
+	if _, err := os.Stat(oldLocalPath); err == nil {
+		if err := os.MkdirAll(filepath.Dir(newLocalPath), 0755); err != nil {
+			return *projectConfig, fmt.Errorf("failed to create parent directory for '%s': %v", newLocalPath, err)
+		}
+		if err := os.Rename(oldLocalPath, newLocalPath); err != nil {
+			return *projectConfig, fmt.Errorf("failed to move local folder '%s' to '%s': %v", oldLocalPath, newLocalPath, err)
+		}
+		movedLocal = true
+	} else if !os.IsNotExist(err) {
+		return *projectConfig, fmt.Errorf("error accessing local path %s: %v", oldLocalPath, err)
+	}
+
+	var rollbackErrs []string
+	rollback := func(stepErr error) (ProjectConfig, error) {
+		if movedLocal {
+			if err := os.Rename(newLocalPath, oldLocalPath); err != nil {
+				rollbackErrs = append(rollbackErrs, fmt.Sprintf("failed to move local folder back to '%s': %v", oldLocalPath, err))
+			}
+		}
+		if len(rollbackErrs) > 0 {
+			return *fs.configManager.GetProjectConfig(), fmt.Errorf("%v; rollback was incomplete: %s", stepErr, strings.Join(rollbackErrs, "; "))
+		}
+		return *fs.configManager.GetProjectConfig(), stepErr
+	}
+
+	// Step 2: Move the remote prefix server-side, if it has any content
+	movedRemote := false
+	sourceFiles, err := remoteFileCount(oldRemotePath)
+	if err != nil {
+		return rollback(fmt.Errorf("failed to check the remote folder: %v", err))
+	}
+	if sourceFiles > 0 {
+		if err := RcloneMove(oldRemotePath, newRemotePath); err != nil {
+			// A partial move may have left files at the destination; move them back
+			if undoErr := RcloneMove(newRemotePath, oldRemotePath); undoErr != nil {
+				rollbackErrs = append(rollbackErrs, fmt.Sprintf("failed to move remote files back to '%s': %v", oldRemotePath, undoErr))
+			}
+			return rollback(fmt.Errorf("failed to move remote folder '%s' to '%s': %v", oldRemotePath, newRemotePath, err))
+		}
+		movedRemote = true
+	}
+
+	// Step 3: Point the folder at its new location in sync.json. The folder's other settings are
+	// taken from the latest config, in case they were edited while the data was moving. The folder
+	// keeps its key, so its status history stays with it.
+	updatedConfig, err := fs.updateProjectConfig(func(latestConfig *ProjectConfig, projectRoot string) error {
+		latestFolderConfig, exists := latestConfig.Folders[folderKey]
+		if !exists || latestFolderConfig.LocalPath != currentConfig.LocalPath || latestFolderConfig.RemotePath != currentConfig.RemotePath {
+			return fmt.Errorf("folder '%s' was changed while it was being moved", folderKey)
+		}
+		latestFolderConfig.LocalPath = movedConfig.LocalPath
+		latestFolderConfig.RemotePath = movedConfig.RemotePath
+		if err := checkFolderOverlap(latestConfig.Folders, folderKey, latestFolderConfig, folderKey); err != nil {
+			return err
+		}
+		latestConfig.Folders[folderKey] = latestFolderConfig
+		return nil
+	})
+	if err != nil {
+		if movedRemote {
+			if undoErr := RcloneMove(newRemotePath, oldRemotePath); undoErr != nil {
+				rollbackErrs = append(rollbackErrs, fmt.Sprintf("failed to move remote files back to '%s': %v", oldRemotePath, undoErr))
+			}
+		}
+		return rollback(err)
+	}
+
+	return updatedConfig, err
+}
+
+// remoteFileCount returns how many files exist under the given remote path. A path that doesn't
+// exist has no files.
+func remoteFileCount(remotePath string) (int, error) {
+	files, err := rcloneListFiles(remotePath)
+	if err != nil {
+		if errorKind(err) == ErrorKindNotFound {
+			return 0, nil
+		}
+		return 0, err
+	}
+	count := 0
+	for _, file := range files {
+		if !file.IsDir {
+			count++
+		}
+	}
+	return count, nil
+}
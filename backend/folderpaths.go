@@ -0,0 +1,245 @@
+package backend
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"sort"
+	"strings"
+)
+
+// driveLetterPattern matches a Windows drive prefix such as "C:" or "c:\".
+var driveLetterPattern = regexp.MustCompile(`^[A-Za-z]:`)
+
+// localPathsFoldCase is true on platforms whose file systems ignore case by default, where
+// "Assets" and "assets" are the same local folder.
+var localPathsFoldCase = runtime.GOOS == "windows" || runtime.GOOS == "darwin"
+
+// validateFolderPath returns an error if a folder path from sync.json could point outside the
+// project. Paths must be relative, must not use a drive letter, must not contain ".." segments,
+// and must not lie in the reserved trash area.
+func validateFolderPath(folderPath string) error {
+	if err := validateRelativePath(folderPath); err != nil {
+		return err
+	}
+	if isPathWithin(path.Clean(normalizePath(folderPath)), trashDirName) {
+		return fmt.Errorf("'%s' is inside the reserved %s area", folderPath, trashDirName)
+	}
+	return nil
+}
+
+// validateRelativePath returns an error unless the path is a relative path strictly below the project root.
+func validateRelativePath(folderPath string) error {
+	slashed := strings.ReplaceAll(folderPath, "\\", "/")
+	switch {
+	case strings.TrimSpace(folderPath) == "":
+		return fmt.Errorf("the path is empty")
+	case strings.HasPrefix(slashed, "/"):
+		return fmt.Errorf("'%s' is an absolute path", folderPath)
+	case driveLetterPattern.MatchString(slashed):
+		return fmt.Errorf("'%s' starts with a drive letter", folderPath)
+	}
+	for _, segment := range strings.Split(slashed, "/") {
+		if segment == ".." {
+			return fmt.Errorf("'%s' contains a '..' segment", folderPath)
+		}
+	}
+	if path.Clean(normalizePath(folderPath)) == "." {
+		return fmt.Errorf("'%s' refers to the project root", folderPath)
+	}
+	return nil
+}
+
+// validateFolderConfigPaths checks both paths of a registered folder and names the folder in the error.
+func validateFolderConfigPaths(folderKey string, folderConfig FolderConfig) error {
+	if err := validateFolderPath(folderConfig.LocalPath); err != nil {
+		return fmt.Errorf("folder '%s' has an invalid local path: %v", folderKey, err)
+	}
+	if err := validateFolderPath(folderConfig.RemotePath); err != nil {
+		return fmt.Errorf("folder '%s' has an invalid remote path: %v", folderKey, err)
+	}
+	return nil
+}
+
+// validateProjectFolderPaths checks the paths of every registered folder and reports all invalid entries.
+func validateProjectFolderPaths(projectConfig *ProjectConfig) error {
+	folderKeys := make([]string, 0, len(projectConfig.Folders))
+	for folderKey := range projectConfig.Folders {
+		folderKeys = append(folderKeys, folderKey)
+	}
+	sort.Strings(folderKeys)
+
+	var problems []string
+	for _, folderKey := range folderKeys {
+		if err := validateFolderConfigPaths(folderKey, projectConfig.Folders[folderKey]); err != nil {
+			problems = append(problems, err.Error())
+		}
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("sync.json contains unsafe folder paths: %s", strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+// resolveFolderLocalPath validates a registered folder and returns its absolute local path. As a
+// last line of defense, the joined path is also checked to still lie inside the project root.
+func resolveFolderLocalPath(projectRoot string, folderKey string, folderConfig FolderConfig) (string, error) {
+	if err := validateFolderConfigPaths(folderKey, folderConfig); err != nil {
+		return "", err
+	}
+	fullLocalPath := filepath.Join(projectRoot, filepath.FromSlash(normalizePath(folderConfig.LocalPath)))
+	relPath, err := filepath.Rel(filepath.Clean(projectRoot), fullLocalPath)
+	if err != nil || relPath == "." || relPath == ".." || strings.HasPrefix(relPath, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("folder '%s' resolves outside the project root: %s", folderKey, fullLocalPath)
+	}
+	return fullLocalPath, nil
+}
+
+// ProjectValidationIssue is a single problem found in the project configuration.
+type ProjectValidationIssue struct {
+	Folders []string `json:"folders"` // Keys of the folders involved
+	Message string   `json:"message"`
+}
+
+// ProjectValidationReport lists every problem found in the project configuration.
+type ProjectValidationReport struct {
+	Valid  bool                     `json:"valid"`
+	Issues []ProjectValidationIssue `json:"issues"`
+}
+
+// folderPathOverlap returns a description of how two folders cover the same files, or an empty
+// string if their local and remote paths are disjoint. Identical or nested paths overlap, since
+// syncing the outer folder would also delete or duplicate the inner folder's content. Local paths
+// are compared without case where the file system ignores it; remote paths always keep case.
+func folderPathOverlap(folderKey string, folderConfig FolderConfig, otherKey string, otherConfig FolderConfig) string {
+	pathTypes := []struct {
+		name     string
+		path     string
+		other    string
+		foldCase bool
+	}{
+		{"local", cleanFolderPath(folderConfig.LocalPath), cleanFolderPath(otherConfig.LocalPath), localPathsFoldCase},
+		{"remote", cleanFolderPath(folderConfig.RemotePath), cleanFolderPath(otherConfig.RemotePath), false},
+	}
+	for _, pathType := range pathTypes {
+		switch {
+		case pathsEqual(pathType.path, pathType.other, pathType.foldCase):
+			return fmt.Sprintf("folders '%s' and '%s' have the same %s path '%s'", folderKey, otherKey, pathType.name, pathType.path)
+		case pathWithinFold(pathType.path, pathType.other, pathType.foldCase):
+			return fmt.Sprintf("the %s path of folder '%s' is inside folder '%s' ('%s')", pathType.name, folderKey, otherKey, pathType.other)
+		case pathWithinFold(pathType.other, pathType.path, pathType.foldCase):
+			return fmt.Sprintf("the %s path of folder '%s' is inside folder '%s' ('%s')", pathType.name, otherKey, folderKey, pathType.path)
+		}
+	}
+	return ""
+}
+
+// cleanFolderPath normalizes a folder path and removes "." segments and repeated slashes.
+func cleanFolderPath(folderPath string) string {
+	return path.Clean(normalizePath(folderPath))
+}
+
+// pathsEqual compares two cleaned paths, ignoring case if foldCase is set.
+func pathsEqual(a string, b string, foldCase bool) bool {
+	if foldCase {
+		return strings.EqualFold(a, b)
+	}
+	return a == b
+}
+
+// pathWithinFold is isPathWithin, ignoring case if foldCase is set.
+func pathWithinFold(path string, folder string, foldCase bool) bool {
+	if pathsEqual(path, folder, foldCase) {
+		return true
+	}
+	prefix := folder + "/"
+	return len(path) > len(prefix) && pathsEqual(path[:len(prefix)], prefix, foldCase)
+}
+
+// checkFolderOverlap returns an error if the given folder would overlap any other registered folder.
+// The folder's own current entry, if any, is ignored.
+func checkFolderOverlap(folders map[string]FolderConfig, folderKey string, folderConfig FolderConfig, ignoreKey string) error {
+	for otherKey, otherConfig := range folders {
+		if otherKey == ignoreKey {
+			continue
+		}
+		if overlap := folderPathOverlap(folderKey, folderConfig, otherKey, otherConfig); overlap != "" {
+			return fmt.Errorf("%s; registered folders must not be nested or share paths", overlap)
+		}
+	}
+	return nil
+}
+
+// checkSelectionOverlap returns an error if any two of the selected folders cover the same files,
+// so an action on the selection would touch them twice.
+func checkSelectionOverlap(projectConfig *ProjectConfig, targetFolders []string) error {
+	for i, folderKey := range targetFolders {
+		folderConfig, exists := projectConfig.Folders[folderKey]
+		if !exists {
+			continue
+		}
+		for _, otherKey := range targetFolders[i+1:] {
+			otherConfig, exists := projectConfig.Folders[otherKey]
+			if !exists || otherKey == folderKey {
+				continue
+			}
+			if overlap := folderPathOverlap(folderKey, folderConfig, otherKey, otherConfig); overlap != "" {
+				return fmt.Errorf("%s; run the action on one of them at a time", overlap)
+			}
+		}
+	}
+	return nil
+}
+
+// validateProjectConfig checks the whole project configuration for overlapping folders and folders
+// that reference missing groups.
+func validateProjectConfig(projectConfig *ProjectConfig) ProjectValidationReport {
+	report := ProjectValidationReport{Issues: []ProjectValidationIssue{}}
+
+	folderKeys := make([]string, 0, len(projectConfig.Folders))
+	for folderKey := range projectConfig.Folders {
+		folderKeys = append(folderKeys, folderKey)
+	}
+	sort.Strings(folderKeys)
+
+	for i, folderKey := range folderKeys {
+		folderConfig := projectConfig.Folders[folderKey]
+		for _, otherKey := range folderKeys[i+1:] {
+			if overlap := folderPathOverlap(folderKey, folderConfig, otherKey, projectConfig.Folders[otherKey]); overlap != "" {
+				report.Issues = append(report.Issues, ProjectValidationIssue{
+					Folders: []string{folderKey, otherKey},
+					Message: overlap,
+				})
+			}
+		}
+		if _, groupExists := projectConfig.Groups[folderConfig.Group]; !groupExists {
+			report.Issues = append(report.Issues, ProjectValidationIssue{
+				Folders: []string{folderKey},
+				Message: fmt.Sprintf("folder '%s' is assigned to group '%s', which does not exist", folderKey, folderConfig.Group),
+			})
+		}
+	}
+
+	report.Valid = len(report.Issues) == 0
+	return report
+}
+
+// ValidateProjectConfig reports every problem in the selected project's configuration.
+func (fs *FolderService) ValidateProjectConfig() (ProjectValidationReport, error) {
+	projectConfig, err := fs.getProjectConfig()
+	if err != nil {
+		return ProjectValidationReport{}, err
+	}
+	return validateProjectConfig(projectConfig), nil
+}
+
+// resolveFolderPaths validates a registered folder and returns its absolute local path and its rclone remote path.
+func resolveFolderPaths(remoteConfig *RemoteConfig, folderKey string, folderConfig FolderConfig) (string, string, error) {
+	fullLocalPath, err := resolveFolderLocalPath(remoteConfig.LocalPath, folderKey, folderConfig)
+	if err != nil {
+		return "", "", err
+	}
+	return fullLocalPath, remoteConfig.remotePath(normalizePath(folderConfig.RemotePath)), nil
+}
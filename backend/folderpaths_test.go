@@ -0,0 +1,43 @@
+package backend
+
+import "testing"
+
+func TestFolderPathOverlap(t *testing.T) {
+	defer func(foldCase bool) { localPathsFoldCase = foldCase }(localPathsFoldCase)
+
+	tests := []struct {
+		name        string
+		local       string
+		remote      string
+		otherLocal  string
+		otherRemote string
+		foldCase    bool
+		overlap     bool
+	}{
+		{"disjoint", "assets", "assets", "renders", "renders", false, false},
+		{"shared prefix only", "assets", "assets", "assets2", "assets2", false, false},
+		{"same local path", "assets", "a", "assets", "b", false, true},
+		{"same remote path", "a", "assets", "b", "assets", false, true},
+		{"nested local path", "assets/textures", "a", "assets", "b", false, true},
+		{"nested remote path", "a", "assets", "b", "assets/textures", false, true},
+		{"backslashes", "assets\\textures", "a", "assets", "b", false, true},
+		{"dot segments", "./assets/./textures", "a", "assets", "b", false, true},
+		{"repeated slashes", "assets//textures", "a", "assets/textures", "b", false, true},
+		{"local case kept", "Assets", "a", "assets", "b", false, false},
+		{"local case folded", "Assets", "a", "assets", "b", true, true},
+		{"nested local case folded", "ASSETS/textures", "a", "assets", "b", true, true},
+		{"remote case never folded", "a", "Assets", "b", "assets", true, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			localPathsFoldCase = tt.foldCase
+			overlap := folderPathOverlap(
+				"one", FolderConfig{LocalPath: tt.local, RemotePath: tt.remote},
+				"two", FolderConfig{LocalPath: tt.otherLocal, RemotePath: tt.otherRemote},
+			)
+			if (overlap != "") != tt.overlap {
+				t.Errorf("folderPathOverlap() = %q, want overlap %v", overlap, tt.overlap)
+			}
+		})
+	}
+}
@@ -4,8 +4,10 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"path"
 	"path/filepath"
 	"runtime"
+	"sort"
 	"strings"
 
 	"github.com/wailsapp/wails/v3/pkg/application"
@@ -44,7 +46,11 @@ func (fs *FolderService) OpenFolder(targetFolder string) error {
 			return fmt.Errorf("no folder with the name %s is configured for selected project %s", targetFolder, fs.configManager.GetSelectedProject())
 		}
 		// Construct the full local path
-		fullLocalPath = filepath.Join(projectRemoteConfig.LocalPath, folderConfig.LocalPath)
+		var err error
+		fullLocalPath, err = resolveFolderLocalPath(projectRemoteConfig.LocalPath, targetFolder, folderConfig)
+		if err != nil {
+			return err
+		}
 	}
 
 	if _, err := os.Stat(fullLocalPath); os.IsNotExist(err) {
@@ -77,11 +83,15 @@ func (fs *FolderService) GetLocalFolders() ([]string, error) {
 		return nil, errors.New("project configuration is not loaded")
 	}
 
-	basePath := fs.configManager.globalConfig.Remotes[selectedProject].LocalPath
+	basePath := fs.configManager.GetGlobalConfig().Remotes[selectedProject].LocalPath
 	existingFolders := []string{}
 
 	for folderKey, folderConfig := range projectConfig.Folders {
-		fullPath := filepath.Join(basePath, folderConfig.LocalPath)
+		fullPath, err := resolveFolderLocalPath(basePath, folderKey, folderConfig)
+		if err != nil {
+			fmt.Printf("[WARN] skipping folder: %v\n", err)
+			continue
+		}
 		if _, err := os.Stat(fullPath); err == nil {
 			existingFolders = append(existingFolders, folderKey)
 		} else if os.IsNotExist(err) {
@@ -107,7 +117,10 @@ func (fs *FolderService) processLocalFolders(targetFolders []string, action stri
 		return errors.New("project configuration is not loaded")
 	}
 
-	// Iterate through the list of target folders
+	// Resolve and validate every target path before touching the file system, so a single
+	// invalid entry can't leave the operation half done
+	basePath := fs.configManager.GetGlobalConfig().Remotes[selectedProject].LocalPath
+	fullPaths := make([]string, 0, len(targetFolders))
 	for _, targetFolder := range targetFolders {
 		// Get the folder configuration
 		folderConfig, exists := projectConfig.Folders[targetFolder]
@@ -116,9 +129,15 @@ func (fs *FolderService) processLocalFolders(targetFolders []string, action stri
 		}
 
 		// Build the full path
-		basePath := fs.configManager.globalConfig.Remotes[selectedProject].LocalPath
-		fullPath := filepath.Join(basePath, folderConfig.LocalPath)
+		fullPath, err := resolveFolderLocalPath(basePath, targetFolder, folderConfig)
+		if err != nil {
+			return err
+		}
+		fullPaths = append(fullPaths, fullPath)
+	}
 
+	// Iterate through the list of target folders
+	for _, fullPath := range fullPaths {
 		// Perform the action based on the method (create or delete)
 		switch action {
 		case "CREATE":
@@ -157,96 +176,128 @@ func (fs *FolderService) DeleteLocalFolders(targetFolders []string) error {
 // Given a new folder name and a new FolderConfig, create a new FolderConfig for it in the ProjectConfig.
 // Return the entire ProjectConfig after, which will contain the fully updated map of Folders.
 func (fs *FolderService) RegisterNewFolder(newFolderName string, folderConfig FolderConfig) (ProjectConfig, error) {
-	// Get the remote config for the selected project
-	projectRemoteConfig, err := fs.getProjectRemoteConfig()
-	if err != nil {
-		return ProjectConfig{}, err
-	}
-
-	// Get the project config
-	projectConfig, err := fs.getProjectConfig()
-	if err != nil {
-		return ProjectConfig{}, err
-	}
+	return fs.updateProjectConfig(func(projectConfig *ProjectConfig, projectRoot string) error {
+		return applyRegisterFolder(projectConfig, projectRoot, newFolderName, folderConfig)
+	})
+}
 
-	// Normalize the local path
-	folderConfig.LocalPath = normalizePath(folderConfig.LocalPath)
-	// Remove projectRemoteConfig.LocalPath prefix from folderConfig.LocalPath
-	folderConfig.LocalPath = strings.TrimPrefix(folderConfig.LocalPath, projectRemoteConfig.LocalPath)
-	// Remove any leading slashes (Unix or Windows style)
-	folderConfig.LocalPath = strings.TrimLeft(folderConfig.LocalPath, `/\`)
+// applyRegisterFolder adds a new folder to the project config, last in its group.
+func applyRegisterFolder(projectConfig *ProjectConfig, projectRoot string, newFolderName string, folderConfig FolderConfig) error {
+	// Normalize the local path relative to the project root
+	folderConfig.LocalPath = normalizeFolderLocalPath(folderConfig.LocalPath, projectRoot)
 	// Set remotePath to be identical to LocalPath
 	folderConfig.RemotePath = folderConfig.LocalPath
+	folderConfig.Tags = normalizeTags(folderConfig.Tags)
 	// Verify no other folder with the same key exists
 	if _, exists := projectConfig.Folders[newFolderName]; exists {
-		return *projectConfig, fmt.Errorf("a folder with the name '%s' is already configured for the selected project", newFolderName)
+		return fmt.Errorf("a folder with the name '%s' is already configured for the selected project", newFolderName)
 	}
 
-	// Verify no other folder has the same LocalPath
-	fullLocalPath := filepath.Join(projectRemoteConfig.LocalPath, folderConfig.LocalPath)
-	for _, existingFolder := range projectConfig.Folders {
-		existingFullPath := filepath.Join(projectRemoteConfig.LocalPath, normalizePath(existingFolder.LocalPath))
-		if fullLocalPath == existingFullPath {
-			return *projectConfig, fmt.Errorf("a folder with the local path '%s' is already configured for the selected project", folderConfig.LocalPath)
-		}
+	// Verify the paths stay inside the project
+	if err := validateFolderConfigPaths(newFolderName, folderConfig); err != nil {
+		return err
+	}
+
+	// Verify the folder doesn't share, nest inside, or contain another folder's paths
+	if err := checkFolderOverlap(projectConfig.Folders, newFolderName, folderConfig, ""); err != nil {
+		return err
 	}
 
 	// Verify the local folder exists
+	fullLocalPath := filepath.Join(projectRoot, folderConfig.LocalPath)
 	if _, err := os.Stat(fullLocalPath); os.IsNotExist(err) {
-		return *projectConfig, fmt.Errorf("folder path does not exist: %s", fullLocalPath)
+		return fmt.Errorf("folder path does not exist: %s", fullLocalPath)
 	}
 
 	// Validate that a group is specified
 	if folderConfig.Group == "" {
-		return *projectConfig, fmt.Errorf("a group must be specified for the folder")
+		return fmt.Errorf("a group must be specified for the folder")
 	}
 
 	// Validate that the specified group exists
-	if projectConfig.Groups == nil {
-		return *projectConfig, fmt.Errorf("no groups exist; create a group first before registering folders")
+	if len(projectConfig.Groups) == 0 {
+		return fmt.Errorf("no groups exist; create a group first before registering folders")
 	}
 	if _, groupExists := projectConfig.Groups[folderConfig.Group]; !groupExists {
-		return *projectConfig, fmt.Errorf("group '%s' does not exist", folderConfig.Group)
+		return fmt.Errorf("group '%s' does not exist", folderConfig.Group)
 	}
 
-	// Add the new key-value pair to the projectConfig
+	// Add the new key-value pair to the projectConfig, last in its group
 	projectConfig.Folders[newFolderName] = folderConfig
-
-	// Set the new project configuration
-	if err := fs.saveAndSyncConfig(projectConfig); err != nil {
-		return *projectConfig, err
-	}
-
-	return *projectConfig, nil
+	placeFolder(projectConfig, newFolderName, folderConfig.Group, folderConfig.Group, -1)
+	return nil
 }
 
 // Given an existing folder, a new folder name, and a new FolderConfig, update the existing folder to match the new items.
 // Return the entire ProjectConfig after, which will contain the fully updated map of Folders.
 func (fs *FolderService) EditFolder(currentFolderName string, newFolderName string, newFolderConfig FolderConfig) (ProjectConfig, error) {
-	// Get the project config.
-	projectConfig, err := fs.getProjectConfig()
-	if err != nil {
-		return ProjectConfig{}, err
+	updatedConfig, err := fs.updateProjectConfig(func(projectConfig *ProjectConfig, projectRoot string) error {
+		return applyEditFolder(projectConfig, projectRoot, currentFolderName, newFolderName, newFolderConfig)
+	})
+	if err == nil {
+		fs.configManager.folderState.rename(fs.configManager.GetSelectedProject(), currentFolderName, newFolderName)
 	}
+	return updatedConfig, err
+}
 
+// applyEditFolder replaces an existing folder's key and config in the project config.
+func applyEditFolder(projectConfig *ProjectConfig, projectRoot string, currentFolderName string, newFolderName string, newFolderConfig FolderConfig) error {
 	// Verify that the given folder to update exists in the configuration.
-	if _, exists := projectConfig.Folders[currentFolderName]; !exists {
-		return *projectConfig, fmt.Errorf("folder '%s' does not exist in the project configuration", currentFolderName)
+	currentFolderConfig, exists := projectConfig.Folders[currentFolderName]
+	if !exists {
+		return fmt.Errorf("folder '%s' does not exist in the project configuration", currentFolderName)
+	}
+
+	// Verify no other folder already uses the new name
+	if currentFolderName != newFolderName {
+		if _, exists := projectConfig.Folders[newFolderName]; exists {
+			return fmt.Errorf("a folder with the name '%s' is already configured for the selected project", newFolderName)
+		}
+	}
+
+	// Normalize the paths the same way registration does
+	newFolderConfig.LocalPath = normalizeFolderLocalPath(newFolderConfig.LocalPath, projectRoot)
+	newFolderConfig.RemotePath = normalizePath(newFolderConfig.RemotePath)
+	newFolderConfig.Tags = normalizeTags(newFolderConfig.Tags)
+	if newFolderConfig.RemotePath == "" {
+		newFolderConfig.RemotePath = newFolderConfig.LocalPath
+	}
+	if newFolderConfig.LocalPath == "" {
+		return fmt.Errorf("a local path must be specified for the folder")
+	}
+	if err := validateFolderConfigPaths(newFolderName, newFolderConfig); err != nil {
+		return err
+	}
+
+	// If the local path changed, verify the new local folder exists
+	if newFolderConfig.LocalPath != normalizePath(currentFolderConfig.LocalPath) {
+		fullLocalPath := filepath.Join(projectRoot, newFolderConfig.LocalPath)
+		if _, err := os.Stat(fullLocalPath); os.IsNotExist(err) {
+			return fmt.Errorf("folder path does not exist: %s", fullLocalPath)
+		}
+	}
+
+	// Verify the folder doesn't share, nest inside, or contain another folder's paths
+	if err := checkFolderOverlap(projectConfig.Folders, newFolderName, newFolderConfig, currentFolderName); err != nil {
+		return err
 	}
 
 	// Validate that a group is specified
 	if newFolderConfig.Group == "" {
-		return *projectConfig, fmt.Errorf("a group must be specified for the folder")
+		return fmt.Errorf("a group must be specified for the folder")
 	}
 
 	// Validate that the specified group exists
-	if projectConfig.Groups == nil {
-		return *projectConfig, fmt.Errorf("no groups exist; create a group first")
+	if len(projectConfig.Groups) == 0 {
+		return fmt.Errorf("no groups exist; create a group first")
 	}
 	if _, groupExists := projectConfig.Groups[newFolderConfig.Group]; !groupExists {
-		return *projectConfig, fmt.Errorf("group '%s' does not exist", newFolderConfig.Group)
+		return fmt.Errorf("group '%s' does not exist", newFolderConfig.Group)
 	}
 
+	// The folder keeps its position unless it moves to another group, where it is placed last
+	newFolderConfig.SortOrder = currentFolderConfig.SortOrder
+
 	if currentFolderName == newFolderName {
 		// If the user has not changed the name of the folder in configuration, just replace the existing FolderConfig object.
 		projectConfig.Folders[currentFolderName] = newFolderConfig
@@ -254,40 +305,254 @@ func (fs *FolderService) EditFolder(currentFolderName string, newFolderName stri
 		// Else, remove the existing key-value pair and replace it with the new one.
 		delete(projectConfig.Folders, currentFolderName)
 		projectConfig.Folders[newFolderName] = newFolderConfig
+		replaceSelectionReferences(projectConfig, "folder", currentFolderName, newFolderName)
 	}
-
-	// Save the config and push it to the remote.
-	if err := fs.saveAndSyncConfig(projectConfig); err != nil {
-		return *projectConfig, err
+	if newFolderConfig.Group != currentFolderConfig.Group {
+		placeFolder(projectConfig, newFolderName, currentFolderConfig.Group, newFolderConfig.Group, -1)
 	}
-
-	return *projectConfig, nil
+	return nil
 }
 
 // Given a target folder, scrub it out of the project configuration's folders. This does NOT delete the folder
-// locally nor remotely. It only untracks it. Full deletions should be carefully handled manually with Rclone
-// for now.
+// locally nor remotely. It only untracks it. To also remove the remote data, use DeleteRemoteFolder, which
+// moves it into the recoverable trash.
 func (fs *FolderService) DeregisterFolder(targetFolder string) (ProjectConfig, error) {
-	// Get the project config.
-	projectConfig, err := fs.getProjectConfig()
-	if err != nil {
-		return ProjectConfig{}, err
-	}
+	return fs.updateProjectConfig(func(projectConfig *ProjectConfig, projectRoot string) error {
+		return applyDeregisterFolder(projectConfig, targetFolder)
+	})
+}
 
+// applyDeregisterFolder removes a folder from the project config.
+func applyDeregisterFolder(projectConfig *ProjectConfig, targetFolder string) error {
 	// Verify the targeted folder exists in the project config.
-	if _, exists := projectConfig.Folders[targetFolder]; !exists {
-		return *projectConfig, fmt.Errorf("folder '%s' does not exist in the project configuration", targetFolder)
+	targetConfig, exists := projectConfig.Folders[targetFolder]
+	if !exists {
+		return fmt.Errorf("folder '%s' does not exist in the project configuration", targetFolder)
 	}
 
 	// Remove the targeted folder's key-value pair from the project configuration's folder map.
 	delete(projectConfig.Folders, targetFolder)
+	applyFolderOrder(projectConfig, sortedGroupFolders(projectConfig, targetConfig.Group))
+	replaceSelectionReferences(projectConfig, "folder", targetFolder, "")
+	return nil
+}
 
-	// Save, set, and push the project configuration.
-	if err := fs.saveAndSyncConfig(projectConfig); err != nil {
-		return *projectConfig, err
+// FolderRegistration is a folder to register under the given key.
+type FolderRegistration struct {
+	FolderKey    string       `json:"folder_key"`
+	FolderConfig FolderConfig `json:"folder_config"`
+}
+
+// FolderRegistrationProposal is a set of folders to register in one go, along with any new groups
+// they need.
+type FolderRegistrationProposal struct {
+	Folders []FolderRegistration   `json:"folders"`
+	Groups  map[string]GroupConfig `json:"groups"` // New groups to create
+}
+
+// ProposeRemoteFolderRegistrations proposes a registration for every remote directory that isn't
+// registered yet. Keys are taken from the directory names and groups from the parent paths, with
+// any missing groups proposed as well. The proposal is meant to be reviewed and edited by the user
+// before being passed to RegisterRemoteFolders.
+func (fs *FolderService) ProposeRemoteFolderRegistrations() (FolderRegistrationProposal, error) {
+	proposal := FolderRegistrationProposal{
+		Folders: []FolderRegistration{},
+		Groups:  make(map[string]GroupConfig),
+	}
+
+	projectConfig, err := fs.getProjectConfig()
+	if err != nil {
+		return proposal, err
+	}
+
+	drift, err := fs.configManager.detectProjectDrift()
+	if err != nil {
+		return proposal, err
+	}
+
+	usedKeys := make(map[string]bool, len(projectConfig.Folders))
+	for folderKey := range projectConfig.Folders {
+		usedKeys[folderKey] = true
+	}
+
+	for _, dir := range drift.UnregisteredRemoteFolders {
+		groupKey := defaultGroupKey
+		if parent := path.Dir(dir); parent != "." {
+			groupKey = proposeGroup(parent, projectConfig, proposal.Groups)
+		} else if _, exists := projectConfig.Groups[defaultGroupKey]; !exists {
+			proposal.Groups[defaultGroupKey] = GroupConfig{Name: "General"}
+		}
+
+		folderKey := path.Base(dir)
+		if usedKeys[folderKey] {
+			folderKey = dir
+		}
+		for suffix := 2; usedKeys[folderKey]; suffix++ {
+			folderKey = fmt.Sprintf("%s (%d)", dir, suffix)
+		}
+		usedKeys[folderKey] = true
+
+		proposal.Folders = append(proposal.Folders, FolderRegistration{
+			FolderKey: folderKey,
+			FolderConfig: FolderConfig{
+				RemotePath: dir,
+				LocalPath:  dir,
+				Group:      groupKey,
+			},
+		})
 	}
 
-	return *projectConfig, nil
+	return proposal, nil
+}
+
+// RegisterRemoteFolders registers all given folders, and creates any new groups they need, in a
+// single sync.json write. Unlike RegisterNewFolder, the folders don't need to exist locally. If
+// any registration is invalid, nothing is registered.
+func (fs *FolderService) RegisterRemoteFolders(proposal FolderRegistrationProposal) (ProjectConfig, error) {
+	return fs.updateProjectConfig(func(updatedConfig *ProjectConfig, projectRoot string) error {
+		return applyRegistrationProposal(updatedConfig, proposal)
+	})
+}
+
+// applyRegistrationProposal adds the proposal's groups and folders to the project config.
+func applyRegistrationProposal(projectConfig *ProjectConfig, proposal FolderRegistrationProposal) error {
+	// Add the new groups first, so the folders can reference them
+	newGroups := []string{}
+	for groupKey, groupConfig := range proposal.Groups {
+		if _, exists := projectConfig.Groups[groupKey]; !exists {
+			projectConfig.Groups[groupKey] = groupConfig
+			newGroups = append(newGroups, groupKey)
+		}
+	}
+	for _, groupKey := range newGroups {
+		parentGroup := projectConfig.Groups[groupKey].ParentGroup
+		if parentGroup == "" {
+			continue
+		}
+		if _, parentExists := projectConfig.Groups[parentGroup]; !parentExists {
+			return fmt.Errorf("parent group '%s' of group '%s' does not exist", parentGroup, groupKey)
+		}
+		if wouldCreateCircularReference(projectConfig, groupKey, parentGroup) {
+			return fmt.Errorf("group '%s' would create a circular group reference", groupKey)
+		}
+	}
+	// Place the new groups after their existing siblings, in name order
+	sort.Slice(newGroups, func(i, j int) bool {
+		a, b := projectConfig.Groups[newGroups[i]], projectConfig.Groups[newGroups[j]]
+		if a.Name != b.Name {
+			return a.Name < b.Name
+		}
+		return newGroups[i] < newGroups[j]
+	})
+	for _, groupKey := range newGroups {
+		placeGroup(projectConfig, groupKey, projectConfig.Groups[groupKey].ParentGroup, len(projectConfig.Groups))
+	}
+
+	for _, registration := range proposal.Folders {
+		folderKey := strings.TrimSpace(registration.FolderKey)
+		if folderKey == "" {
+			return fmt.Errorf("a folder name must be specified for '%s'", registration.FolderConfig.LocalPath)
+		}
+		if _, exists := projectConfig.Folders[folderKey]; exists {
+			return fmt.Errorf("a folder with the name '%s' is already configured for the selected project", folderKey)
+		}
+
+		folderConfig := registration.FolderConfig
+		if folderConfig.RemotePath == "" {
+			folderConfig.RemotePath = folderConfig.LocalPath
+		}
+		if err := validateFolderConfigPaths(folderKey, folderConfig); err != nil {
+			return err
+		}
+		folderConfig.LocalPath = normalizePath(folderConfig.LocalPath)
+		folderConfig.RemotePath = normalizePath(folderConfig.RemotePath)
+		folderConfig.Tags = normalizeTags(folderConfig.Tags)
+		if folderConfig.LocalPath == "" {
+			return fmt.Errorf("folder '%s' must have a local path", folderKey)
+		}
+		if err := checkFolderOverlap(projectConfig.Folders, folderKey, folderConfig, ""); err != nil {
+			return err
+		}
+
+		if folderConfig.Group == "" {
+			return fmt.Errorf("a group must be specified for folder '%s'", folderKey)
+		}
+		if _, groupExists := projectConfig.Groups[folderConfig.Group]; !groupExists {
+			return fmt.Errorf("group '%s' of folder '%s' does not exist", folderConfig.Group, folderKey)
+		}
+
+		projectConfig.Folders[folderKey] = folderConfig
+		placeFolder(projectConfig, folderKey, folderConfig.Group, folderConfig.Group, -1)
+	}
+	return nil
+}
+
+// proposeGroup returns the key of the group for the given directory, adding it (and any missing
+// ancestor groups) to the proposed groups if no such group exists yet. A directory whose name
+// has no letters or digits the key can keep gets a generated "group-N" key instead.
+func proposeGroup(dir string, projectConfig *ProjectConfig, proposedGroups map[string]GroupConfig) string {
+	groupKey := slugify(dir)
+	if strings.Trim(groupKey, "-") == "" {
+		groupKey = ""
+	}
+	if _, exists := projectConfig.Groups[groupKey]; exists && groupKey != "" {
+		return groupKey
+	}
+
+	parentGroup := ""
+	if parent := path.Dir(dir); parent != "." {
+		parentGroup = proposeGroup(parent, projectConfig, proposedGroups)
+	}
+	groupConfig := GroupConfig{
+		Name:        path.Base(dir),
+		ParentGroup: parentGroup,
+	}
+
+	// Another folder in the same directory already proposed its group
+	for proposedKey, proposed := range proposedGroups {
+		if proposed.Name == groupConfig.Name && proposed.ParentGroup == groupConfig.ParentGroup {
+			return proposedKey
+		}
+	}
+
+	groupKey = uniqueGroupKey(groupKey, projectConfig, proposedGroups)
+	proposedGroups[groupKey] = groupConfig
+	return groupKey
+}
+
+// uniqueGroupKey returns the slug if no group uses it yet, or else the first free key made of
+// the slug (or "group" for an empty slug) and a number.
+func uniqueGroupKey(slug string, projectConfig *ProjectConfig, proposedGroups map[string]GroupConfig) string {
+	taken := func(groupKey string) bool {
+		_, existing := projectConfig.Groups[groupKey]
+		_, proposed := proposedGroups[groupKey]
+		return existing || proposed
+	}
+	if slug != "" && !taken(slug) {
+		return slug
+	}
+	base := slug
+	if base == "" {
+		base = "group"
+	}
+	for n := 1; ; n++ {
+		if groupKey := fmt.Sprintf("%s-%d", base, n); !taken(groupKey) {
+			return groupKey
+		}
+	}
+}
+
+// slugify turns a name or path into a key the same way the frontend generates group keys.
+func slugify(name string) string {
+	slug := strings.ToLower(strings.TrimSpace(name))
+	slug = strings.ReplaceAll(slug, "/", "-")
+	slug = strings.Join(strings.Fields(slug), "-")
+	return strings.Map(func(r rune) rune {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') || r == '-' {
+			return r
+		}
+		return -1
+	}, slug)
 }
 
 // Wrapper method to get the project config from the config manager
@@ -309,27 +574,39 @@ func (fs *FolderService) getProjectRemoteConfig() (*RemoteConfig, error) {
 	return projectRemoteConfig, nil
 }
 
-// Common method to save the project sync.json file to disk, then push it up to the remote
-func (fs *FolderService) saveAndSyncConfig(projectConfig *ProjectConfig) error {
+// updateProjectConfig applies a change to a copy of the project configuration, saves the copy to
+// sync.json and makes it the current configuration, then pushes sync.json up to the remote. If the
+// change or the save fails, the current configuration is left untouched. If only the push fails,
+// the change stands and the upload is queued and retried in the background. Changes are serialized
+// by the config manager, so each one sees the result of the ones before it.
+func (fs *FolderService) updateProjectConfig(change func(projectConfig *ProjectConfig, projectRoot string) error) (ProjectConfig, error) {
 	projectRemoteConfig, err := fs.getProjectRemoteConfig()
 	if err != nil {
-		return err
+		return ProjectConfig{}, err
 	}
-
-	projectPath := projectRemoteConfig.LocalPath
-	configFile := filepath.Join(projectPath, "sync.json")
-
-	if err := saveConfig(configFile, projectConfig); err != nil {
-		return fmt.Errorf("failed to save updated project configuration: %w", err)
+	projectConfig, err := fs.getProjectConfig()
+	if err != nil {
+		return ProjectConfig{}, err
 	}
 
-	fs.configManager.SetProjectConfig(projectConfig)
+	configFile := filepath.Join(projectRemoteConfig.LocalPath, "sync.json")
+	updatedConfig, err := fs.configManager.UpdateProjectConfig(func(latestConfig *ProjectConfig) error {
+		if err := change(latestConfig, projectRemoteConfig.LocalPath); err != nil {
+			return err
+		}
+		if err := saveConfig(configFile, latestConfig); err != nil {
+			return fmt.Errorf("failed to save updated project configuration: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return *projectConfig, err
+	}
 
 	if err := fs.configManager.syncConfigToRemote(); err != nil {
-		return fmt.Errorf("failed to sync the project configuration to the remote: %v", err)
+		fmt.Printf("Warning: Failed to sync the project configuration to the remote, queued for retry: %v\n", err)
 	}
-
-	return nil
+	return *updatedConfig, nil
 }
 
 // Util method to clean a given path string
@@ -339,6 +616,22 @@ func normalizePath(path string) string {
 	return strings.Trim(normalized, "/")
 }
 
+// normalizeFolderLocalPath normalizes a folder's local path and makes it relative to the project root.
+func normalizeFolderLocalPath(localPath string, projectRoot string) string {
+	normalized := normalizePath(localPath)
+	// Remove the project root prefix, if the path was given as an absolute path
+	if root := normalizePath(projectRoot); root != "" && isPathWithin(normalized, root) {
+		normalized = strings.TrimPrefix(normalized, root)
+	}
+	// Remove any leading slashes (Unix or Windows style)
+	return strings.TrimLeft(normalized, `/\`)
+}
+
+// isPathWithin reports whether the normalized path is the given folder or lies inside it.
+func isPathWithin(path string, folder string) bool {
+	return path == folder || strings.HasPrefix(path, folder+"/")
+}
+
 // OpenFolderPicker opens a native OS folder selection dialog and returns the selected path.
 // The returned path is relative to the project root. Returns an empty string if cancelled.
 func (fs *FolderService) OpenFolderPicker() (string, error) {
@@ -392,14 +685,17 @@ func (fs *FolderService) OpenFolderPicker() (string, error) {
 
 // ==================== Group Management Methods ====================
 
-// CreateGroup creates a new group in the project configuration.
+// CreateGroup creates a new group in the project configuration at the given position among its
+// siblings; a negative index adds it after them. The sort order in groupConfig is ignored.
 // Returns the updated ProjectConfig or an error if the group already exists.
-func (fs *FolderService) CreateGroup(groupKey string, groupConfig GroupConfig) (ProjectConfig, error) {
-	projectConfig, err := fs.getProjectConfig()
-	if err != nil {
-		return ProjectConfig{}, err
-	}
+func (fs *FolderService) CreateGroup(groupKey string, groupConfig GroupConfig, index int) (ProjectConfig, error) {
+	return fs.updateProjectConfig(func(projectConfig *ProjectConfig, projectRoot string) error {
+		return applyCreateGroup(projectConfig, groupKey, groupConfig, index)
+	})
+}
 
+// applyCreateGroup adds a new group to the project config.
+func applyCreateGroup(projectConfig *ProjectConfig, groupKey string, groupConfig GroupConfig, index int) error {
 	// Ensure groups map is initialized
 	if projectConfig.Groups == nil {
 		projectConfig.Groups = make(map[string]GroupConfig)
@@ -407,125 +703,216 @@ func (fs *FolderService) CreateGroup(groupKey string, groupConfig GroupConfig) (
 
 	// Check if group already exists
 	if _, exists := projectConfig.Groups[groupKey]; exists {
-		return *projectConfig, fmt.Errorf("a group with the key '%s' already exists", groupKey)
+		return fmt.Errorf("a group with the key '%s' already exists", groupKey)
 	}
 
 	// Validate parent group if specified
 	if groupConfig.ParentGroup != "" {
 		if _, parentExists := projectConfig.Groups[groupConfig.ParentGroup]; !parentExists {
-			return *projectConfig, fmt.Errorf("parent group '%s' does not exist", groupConfig.ParentGroup)
+			return fmt.Errorf("parent group '%s' does not exist", groupConfig.ParentGroup)
 		}
 		// Check for circular reference (parent can't be self)
 		if groupConfig.ParentGroup == groupKey {
-			return *projectConfig, fmt.Errorf("group cannot be its own parent")
+			return fmt.Errorf("group cannot be its own parent")
 		}
 	}
 
-	// Add the new group
+	// Add the new group at the requested position among its siblings, or last for a negative index
 	projectConfig.Groups[groupKey] = groupConfig
-
-	// Save and sync
-	if err := fs.saveAndSyncConfig(projectConfig); err != nil {
-		return *projectConfig, err
-	}
-
-	return *projectConfig, nil
+	placeGroup(projectConfig, groupKey, groupConfig.ParentGroup, index)
+	return nil
 }
 
-// UpdateGroup updates an existing group's properties.
+// UpdateGroup updates an existing group's properties and moves it to the given position among its
+// siblings. A negative index keeps its current position, or adds it last under a new parent. The
+// sort order in groupConfig is ignored.
 // Returns the updated ProjectConfig or an error if the group doesn't exist.
-func (fs *FolderService) UpdateGroup(groupKey string, groupConfig GroupConfig) (ProjectConfig, error) {
-	projectConfig, err := fs.getProjectConfig()
-	if err != nil {
-		return ProjectConfig{}, err
-	}
+func (fs *FolderService) UpdateGroup(groupKey string, groupConfig GroupConfig, index int) (ProjectConfig, error) {
+	return fs.updateProjectConfig(func(projectConfig *ProjectConfig, projectRoot string) error {
+		return applyUpdateGroup(projectConfig, groupKey, groupConfig, index)
+	})
+}
 
+// applyUpdateGroup replaces an existing group's config in the project config.
+func applyUpdateGroup(projectConfig *ProjectConfig, groupKey string, groupConfig GroupConfig, index int) error {
 	// Check if group exists
 	if _, exists := projectConfig.Groups[groupKey]; !exists {
-		return *projectConfig, fmt.Errorf("group '%s' does not exist", groupKey)
+		return fmt.Errorf("group '%s' does not exist", groupKey)
 	}
 
 	// Validate parent group if specified
 	if groupConfig.ParentGroup != "" {
 		if _, parentExists := projectConfig.Groups[groupConfig.ParentGroup]; !parentExists {
-			return *projectConfig, fmt.Errorf("parent group '%s' does not exist", groupConfig.ParentGroup)
+			return fmt.Errorf("parent group '%s' does not exist", groupConfig.ParentGroup)
 		}
 		// Check for circular reference
 		if groupConfig.ParentGroup == groupKey {
-			return *projectConfig, fmt.Errorf("group cannot be its own parent")
+			return fmt.Errorf("group cannot be its own parent")
 		}
 		// Check for deeper circular references
-		if fs.wouldCreateCircularReference(projectConfig, groupKey, groupConfig.ParentGroup) {
-			return *projectConfig, fmt.Errorf("this would create a circular group reference")
+		if wouldCreateCircularReference(projectConfig, groupKey, groupConfig.ParentGroup) {
+			return fmt.Errorf("this would create a circular group reference")
 		}
 	}
 
-	// Update the group
+	// Update the group, moving it to the requested position among its (possibly new) siblings
+	currentConfig := projectConfig.Groups[groupKey]
+	newParent := groupConfig.ParentGroup
+	if index < 0 && newParent == currentConfig.ParentGroup {
+		index = keyIndex(sortedChildGroups(projectConfig, newParent), groupKey)
+	}
+	groupConfig.ParentGroup = currentConfig.ParentGroup
+	groupConfig.SortOrder = currentConfig.SortOrder
 	projectConfig.Groups[groupKey] = groupConfig
+	placeGroup(projectConfig, groupKey, newParent, index)
+	return nil
+}
 
-	// Save and sync
-	if err := fs.saveAndSyncConfig(projectConfig); err != nil {
-		return *projectConfig, err
-	}
+// Modes for DeleteGroup.
+const (
+	GroupDeleteRefuse   = ""         // Refuse while the group has folders or child groups
+	GroupDeleteReassign = "reassign" // Move the group's folders and child groups into the target group
+	GroupDeleteCascade  = "cascade"  // Delete the group's whole subtree and move all of its folders into the target group
+)
 
-	return *projectConfig, nil
+// DeleteGroup removes a group from the project configuration. By default it fails if the group
+// contains folders or has child groups. In "reassign" mode, the group's folders and child groups
+// are moved into targetGroup; in "cascade" mode, the group and all of its descendant groups are
+// deleted and every folder in them is moved into targetGroup. Either way, everything is saved and
+// synced in a single write.
+func (fs *FolderService) DeleteGroup(groupKey string, mode string, targetGroup string) (ProjectConfig, error) {
+	return fs.updateProjectConfig(func(projectConfig *ProjectConfig, projectRoot string) error {
+		return applyDeleteGroup(projectConfig, groupKey, mode, targetGroup)
+	})
 }
 
-// DeleteGroup removes a group from the project configuration.
-// Fails if the group contains folders or has child groups.
-func (fs *FolderService) DeleteGroup(groupKey string) (ProjectConfig, error) {
-	projectConfig, err := fs.getProjectConfig()
-	if err != nil {
-		return ProjectConfig{}, err
-	}
-
+// applyDeleteGroup removes a group from the project config, using the given DeleteGroup mode.
+func applyDeleteGroup(projectConfig *ProjectConfig, groupKey string, mode string, targetGroup string) error {
 	// Check if group exists
 	if _, exists := projectConfig.Groups[groupKey]; !exists {
-		return *projectConfig, fmt.Errorf("group '%s' does not exist", groupKey)
+		return fmt.Errorf("group '%s' does not exist", groupKey)
 	}
 
-	// Check if any folders are in this group
-	for folderName, folder := range projectConfig.Folders {
-		if folder.Group == groupKey {
-			return *projectConfig, fmt.Errorf("cannot delete group '%s': folder '%s' is assigned to it. Move the folder to another group first", groupKey, folderName)
+	subtree := groupWithDescendants(projectConfig, groupKey)
+	var deletedGroups map[string]bool
+	switch mode {
+	case GroupDeleteRefuse:
+		// Check if any folders are in this group
+		for folderName, folder := range projectConfig.Folders {
+			if folder.Group == groupKey {
+				return fmt.Errorf("cannot delete group '%s': folder '%s' is assigned to it. Move the folder to another group first", groupKey, folderName)
+			}
 		}
+
+		// Check if any groups have this as parent
+		for childKey, childGroup := range projectConfig.Groups {
+			if childGroup.ParentGroup == groupKey {
+				return fmt.Errorf("cannot delete group '%s': group '%s' is a child of it. Delete or move child groups first", groupKey, childKey)
+			}
+		}
+		deletedGroups = map[string]bool{groupKey: true}
+	case GroupDeleteReassign:
+		deletedGroups = map[string]bool{groupKey: true}
+	case GroupDeleteCascade:
+		deletedGroups = subtree
+	default:
+		return fmt.Errorf("invalid delete mode '%s'", mode)
 	}
 
-	// Check if any groups have this as parent
-	for childKey, childGroup := range projectConfig.Groups {
-		if childGroup.ParentGroup == groupKey {
-			return *projectConfig, fmt.Errorf("cannot delete group '%s': group '%s' is a child of it. Delete or move child groups first", groupKey, childKey)
+	// Folders that lose their group need a target to move into
+	movedFolders := []string{}
+	for deletedKey := range deletedGroups {
+		movedFolders = append(movedFolders, sortedGroupFolders(projectConfig, deletedKey)...)
+	}
+	if mode != GroupDeleteRefuse {
+		if _, exists := projectConfig.Groups[targetGroup]; !exists && (len(movedFolders) > 0 || targetGroup != "") {
+			return fmt.Errorf("target group '%s' does not exist", targetGroup)
+		}
+		if subtree[targetGroup] {
+			return fmt.Errorf("target group '%s' is inside the group being deleted", targetGroup)
 		}
 	}
 
-	// Delete the group
-	delete(projectConfig.Groups, groupKey)
+	parentKey := projectConfig.Groups[groupKey].ParentGroup
+	if mode == GroupDeleteReassign {
+		for _, childKey := range sortedChildGroups(projectConfig, groupKey) {
+			placeGroup(projectConfig, childKey, targetGroup, len(sortedChildGroups(projectConfig, targetGroup)))
+		}
+	}
+	for _, folderKey := range movedFolders {
+		folderConfig := projectConfig.Folders[folderKey]
+		oldGroup := folderConfig.Group
+		folderConfig.Group = targetGroup
+		projectConfig.Folders[folderKey] = folderConfig
+		placeFolder(projectConfig, folderKey, oldGroup, targetGroup, -1)
+	}
 
-	// Save and sync
-	if err := fs.saveAndSyncConfig(projectConfig); err != nil {
-		return *projectConfig, err
+	// Delete the groups
+	for deletedKey := range deletedGroups {
+		delete(projectConfig.Groups, deletedKey)
+		replaceSelectionReferences(projectConfig, "group", deletedKey, "")
 	}
+	applyGroupOrder(projectConfig, sortedChildGroups(projectConfig, parentKey))
+	return nil
+}
 
-	return *projectConfig, nil
+// MergeGroups merges the source group into the destination group: the source's folders and child
+// groups are moved into the destination, selections that referenced the source now reference the
+// destination, and the source group is removed. Everything is saved and synced in a single write.
+func (fs *FolderService) MergeGroups(sourceGroup string, destinationGroup string) (ProjectConfig, error) {
+	return fs.updateProjectConfig(func(projectConfig *ProjectConfig, projectRoot string) error {
+		return applyMergeGroups(projectConfig, sourceGroup, destinationGroup)
+	})
+}
+
+// applyMergeGroups merges the source group into the destination group in the project config.
+func applyMergeGroups(projectConfig *ProjectConfig, sourceGroup string, destinationGroup string) error {
+	if _, exists := projectConfig.Groups[sourceGroup]; !exists {
+		return fmt.Errorf("group '%s' does not exist", sourceGroup)
+	}
+	if _, exists := projectConfig.Groups[destinationGroup]; !exists {
+		return fmt.Errorf("group '%s' does not exist", destinationGroup)
+	}
+	if groupWithDescendants(projectConfig, sourceGroup)[destinationGroup] {
+		return fmt.Errorf("cannot merge group '%s' into '%s', which is the same group or inside it", sourceGroup, destinationGroup)
+	}
+
+	for _, childKey := range sortedChildGroups(projectConfig, sourceGroup) {
+		placeGroup(projectConfig, childKey, destinationGroup, len(sortedChildGroups(projectConfig, destinationGroup)))
+	}
+	for _, folderKey := range sortedGroupFolders(projectConfig, sourceGroup) {
+		folderConfig := projectConfig.Folders[folderKey]
+		folderConfig.Group = destinationGroup
+		projectConfig.Folders[folderKey] = folderConfig
+		placeFolder(projectConfig, folderKey, sourceGroup, destinationGroup, -1)
+	}
+
+	parentKey := projectConfig.Groups[sourceGroup].ParentGroup
+	delete(projectConfig.Groups, sourceGroup)
+	applyGroupOrder(projectConfig, sortedChildGroups(projectConfig, parentKey))
+	replaceSelectionReferences(projectConfig, "group", sourceGroup, destinationGroup)
+	return nil
 }
 
 // RenameGroup changes a group's key while preserving all folder assignments.
 func (fs *FolderService) RenameGroup(oldKey string, newKey string, newName string) (ProjectConfig, error) {
-	projectConfig, err := fs.getProjectConfig()
-	if err != nil {
-		return ProjectConfig{}, err
-	}
+	return fs.updateProjectConfig(func(projectConfig *ProjectConfig, projectRoot string) error {
+		return applyRenameGroup(projectConfig, oldKey, newKey, newName)
+	})
+}
 
+// applyRenameGroup changes a group's key and name in the project config.
+func applyRenameGroup(projectConfig *ProjectConfig, oldKey string, newKey string, newName string) error {
 	// Check if old group exists
 	oldGroup, exists := projectConfig.Groups[oldKey]
 	if !exists {
-		return *projectConfig, fmt.Errorf("group '%s' does not exist", oldKey)
+		return fmt.Errorf("group '%s' does not exist", oldKey)
 	}
 
 	// Check if new key already exists (and isn't the same as old)
 	if oldKey != newKey {
 		if _, exists := projectConfig.Groups[newKey]; exists {
-			return *projectConfig, fmt.Errorf("a group with the key '%s' already exists", newKey)
+			return fmt.Errorf("a group with the key '%s' already exists", newKey)
 		}
 	}
 
@@ -552,32 +939,24 @@ func (fs *FolderService) RenameGroup(oldKey string, newKey string, newName strin
 	// Delete old and add new
 	delete(projectConfig.Groups, oldKey)
 	projectConfig.Groups[newKey] = newGroup
-
-	// Save and sync
-	if err := fs.saveAndSyncConfig(projectConfig); err != nil {
-		return *projectConfig, err
-	}
-
-	return *projectConfig, nil
+	replaceSelectionReferences(projectConfig, "group", oldKey, newKey)
+	return nil
 }
 
-// GetGroups returns all groups in the project configuration.
-func (fs *FolderService) GetGroups() (map[string]GroupConfig, error) {
+// GetGroups returns all groups in the project configuration as a tree, with groups and the folders
+// inside each group in display order.
+func (fs *FolderService) GetGroups() ([]GroupTreeNode, error) {
 	projectConfig, err := fs.getProjectConfig()
 	if err != nil {
 		return nil, err
 	}
 
-	if projectConfig.Groups == nil {
-		return make(map[string]GroupConfig), nil
-	}
-
-	return projectConfig.Groups, nil
+	return buildGroupTree(projectConfig, "", make(map[string]bool)), nil
 }
 
 // wouldCreateCircularReference checks if setting parentKey as the parent of groupKey
 // would create a circular reference in the group hierarchy.
-func (fs *FolderService) wouldCreateCircularReference(config *ProjectConfig, groupKey, parentKey string) bool {
+func wouldCreateCircularReference(config *ProjectConfig, groupKey, parentKey string) bool {
 	visited := make(map[string]bool)
 	current := parentKey
 
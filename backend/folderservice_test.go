@@ -0,0 +1,80 @@
+package backend
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestApplyRegistrationProposalPlacesNewGroupsLast(t *testing.T) {
+	projectConfig := &ProjectConfig{
+		Folders: map[string]FolderConfig{},
+		Groups: map[string]GroupConfig{
+			"assets": {Name: "Assets", SortOrder: 0},
+			"shots":  {Name: "Shots", SortOrder: 1},
+		},
+	}
+	proposal := FolderRegistrationProposal{
+		Folders: []FolderRegistration{
+			{FolderKey: "sh010", FolderConfig: FolderConfig{LocalPath: "shots/seq01/sh010", Group: "shots-seq01"}},
+			{FolderKey: "audio", FolderConfig: FolderConfig{LocalPath: "audio/mix", Group: "audio"}},
+		},
+		// Proposed groups all come with a sort order of 0
+		Groups: map[string]GroupConfig{
+			"audio":       {Name: "audio"},
+			"editorial":   {Name: "editorial"},
+			"shots-seq01": {Name: "seq01", ParentGroup: "shots"},
+		},
+	}
+
+	if err := applyRegistrationProposal(projectConfig, proposal); err != nil {
+		t.Fatalf("applyRegistrationProposal failed: %v", err)
+	}
+	if got, want := sortedChildGroups(projectConfig, ""), []string{"assets", "shots", "audio", "editorial"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("top-level groups = %v, want %v", got, want)
+	}
+	for i, groupKey := range sortedChildGroups(projectConfig, "") {
+		if projectConfig.Groups[groupKey].SortOrder != i {
+			t.Errorf("group %q has sort order %d, want %d", groupKey, projectConfig.Groups[groupKey].SortOrder, i)
+		}
+	}
+	if got := projectConfig.Groups["shots-seq01"].SortOrder; got != 0 {
+		t.Errorf("nested group sort order = %d, want 0", got)
+	}
+}
+
+func TestProposeGroupKeys(t *testing.T) {
+	projectConfig := &ProjectConfig{
+		Groups: map[string]GroupConfig{
+			"group-1": {Name: "Existing"},
+			"assets":  {Name: "Assets"},
+		},
+	}
+	proposedGroups := map[string]GroupConfig{}
+
+	tests := []struct {
+		dir        string
+		wantKey    string
+		wantParent string
+	}{
+		{"assets", "assets", ""},
+		{"Проект", "group-2", ""},
+		{"日本", "group-3", ""},
+		{"Проект", "group-2", ""},
+		{"Проект/日本", "group-4", "group-2"},
+		{"a b", "a-b", ""},
+		{"a-b", "a-b-1", ""},
+	}
+	for _, tt := range tests {
+		groupKey := proposeGroup(tt.dir, projectConfig, proposedGroups)
+		if groupKey != tt.wantKey {
+			t.Errorf("proposeGroup(%q) = %q, want %q", tt.dir, groupKey, tt.wantKey)
+			continue
+		}
+		if groupConfig, proposed := proposedGroups[groupKey]; proposed && groupConfig.ParentGroup != tt.wantParent {
+			t.Errorf("group %q has parent %q, want %q", groupKey, groupConfig.ParentGroup, tt.wantParent)
+		}
+	}
+	if _, proposed := proposedGroups["assets"]; proposed {
+		t.Errorf("the existing group 'assets' was proposed again")
+	}
+}
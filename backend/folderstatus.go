@@ -0,0 +1,283 @@
+package backend
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Dirty states reported in FolderStatus.
+const (
+	DirtyStateClean   = "clean"
+	DirtyStateDirty   = "dirty"
+	DirtyStateUnknown = "unknown"
+)
+
+// FolderStatus is everything the dashboard shows about one registered folder.
+type FolderStatus struct {
+	FolderKey       string `json:"folder_key"`
+	ExistsLocally   bool   `json:"exists_locally"`
+	LocalSize       int64  `json:"local_size"`
+	LocalFileCount  int    `json:"local_file_count"`
+	RemoteSize      int64  `json:"remote_size"`       // As of RemoteCheckedAt
+	RemoteFileCount int    `json:"remote_file_count"` // As of RemoteCheckedAt
+	RemoteCheckedAt string `json:"remote_checked_at"` // RFC3339; empty if the remote was never measured
+	LastPush        string `json:"last_push"`         // RFC3339; last successful push from this machine
+	LastPull        string `json:"last_pull"`         // RFC3339; last successful pull to this machine
+	DirtyState      string `json:"dirty_state"`       // "clean", "dirty", or "unknown"
+	DirtyCheckedAt  string `json:"dirty_checked_at"`  // RFC3339; when the dirty state was last determined
+	Error           string `json:"error"`
+}
+
+// folderState is what this machine remembers about a folder between sessions.
+type folderState struct {
+	LastPush        string `json:"last_push,omitempty"`
+	LastPull        string `json:"last_pull,omitempty"`
+	DirtyState      string `json:"dirty_state,omitempty"`
+	DirtyCheckedAt  string `json:"dirty_checked_at,omitempty"`
+	RemoteSize      int64  `json:"remote_size"`
+	RemoteFileCount int    `json:"remote_file_count"`
+	RemoteCheckedAt string `json:"remote_checked_at,omitempty"`
+}
+
+// folderStateStore persists per-machine folder state, keyed by project and then by folder key,
+// in folder_state.json next to config.json. It is loaded on first use.
+type folderStateStore struct {
+	projects map[string]map[string]folderState
+	loaded   bool
+	mu       sync.Mutex
+}
+
+func newFolderStateStore() *folderStateStore {
+	return &folderStateStore{projects: make(map[string]map[string]folderState)}
+}
+
+// get returns a copy of the stored state of every folder in the project.
+func (fss *folderStateStore) get(project string) map[string]folderState {
+	fss.mu.Lock()
+	defer fss.mu.Unlock()
+	fss.load()
+
+	states := make(map[string]folderState, len(fss.projects[project]))
+	for folderKey, state := range fss.projects[project] {
+		states[folderKey] = state
+	}
+	return states
+}
+
+// update applies the given change to each folder's state and writes the store to disk.
+func (fss *folderStateStore) update(project string, folderKeys []string, change func(folderKey string, state *folderState)) {
+	fss.mu.Lock()
+	defer fss.mu.Unlock()
+	fss.load()
+
+	if fss.projects[project] == nil {
+		fss.projects[project] = make(map[string]folderState)
+	}
+	for _, folderKey := range folderKeys {
+		state := fss.projects[project][folderKey]
+		change(folderKey, &state)
+		fss.projects[project][folderKey] = state
+	}
+	if err := fss.save(); err != nil {
+		fmt.Printf("Warning: Failed to save folder state: %v\n", err)
+	}
+}
+
+// rename moves a folder's state to its new key, so a renamed folder keeps its history.
+func (fss *folderStateStore) rename(project string, oldKey string, newKey string) {
+	fss.mu.Lock()
+	defer fss.mu.Unlock()
+	fss.load()
+
+	state, exists := fss.projects[project][oldKey]
+	if !exists || oldKey == newKey {
+		return
+	}
+	delete(fss.projects[project], oldKey)
+	fss.projects[project][newKey] = state
+	if err := fss.save(); err != nil {
+		fmt.Printf("Warning: Failed to save folder state: %v\n", err)
+	}
+}
+
+// load reads the store from disk once. A missing or unreadable file starts an empty store, since
+// the state is only informational.
+func (fss *folderStateStore) load() {
+	if fss.loaded {
+		return
+	}
+	fss.loaded = true
+
+	path, err := folderStatePath()
+	if err != nil {
+		return
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return
+	}
+	if err := json.Unmarshal(data, &fss.projects); err != nil || fss.projects == nil {
+		fmt.Printf("Warning: Ignoring unreadable folder state: %v\n", err)
+		fss.projects = make(map[string]map[string]folderState)
+	}
+}
+
+func (fss *folderStateStore) save() error {
+	path, err := folderStatePath()
+	if err != nil {
+		return err
+	}
+	return saveConfig(path, fss.projects)
+}
+
+// folderStatePath returns the path of folder_state.json in the app config directory.
+func folderStatePath() (string, error) {
+	configDir, err := getAppConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(configDir, "folder_state.json"), nil
+}
+
+// recordActionResult remembers a successful push or pull of a folder. A push or sync pull leaves
+// the local and remote copies identical; a copy pull may leave local-only files behind.
+func (ss *SyncService) recordActionResult(targetFolder string, action RcloneAction) {
+	now := time.Now().UTC().Format(time.RFC3339)
+	ss.configManager.folderState.update(ss.configManager.GetSelectedProject(), []string{targetFolder}, func(_ string, state *folderState) {
+		switch action {
+		case SYNC_PUSH:
+			state.LastPush = now
+			state.DirtyState = DirtyStateClean
+		case SYNC_PULL:
+			state.LastPull = now
+			state.DirtyState = DirtyStateClean
+		case COPY_PULL:
+			state.LastPull = now
+			state.DirtyState = DirtyStateUnknown
+		}
+		state.DirtyCheckedAt = now
+	})
+}
+
+// recordDirtyState remembers the outcome of change detection for a folder.
+func (ss *SyncService) recordDirtyState(targetFolder string, hasChanges bool) {
+	now := time.Now().UTC().Format(time.RFC3339)
+	ss.configManager.folderState.update(ss.configManager.GetSelectedProject(), []string{targetFolder}, func(_ string, state *folderState) {
+		state.DirtyState = DirtyStateClean
+		if hasChanges {
+			state.DirtyState = DirtyStateDirty
+		}
+		state.DirtyCheckedAt = now
+	})
+}
+
+// GetFolderStatuses returns the status of every registered folder in one call. Local sizes are
+// measured on every call. Remote sizes come from a cache, which is refreshed with a single listing
+// of the whole remote when refreshRemote is set. The dirty state is the outcome of the last change
+// detection, push, or pull on this machine.
+func (ss *SyncService) GetFolderStatuses(refreshRemote bool) ([]FolderStatus, error) {
+	remoteConfig := ss.configManager.GetSelectedProjectRemoteConfig()
+	if remoteConfig == nil {
+		return nil, fmt.Errorf("selected project's remote configuration is not available")
+	}
+	projectConfig := ss.configManager.GetProjectConfig()
+	if projectConfig == nil {
+		return nil, fmt.Errorf("project configuration is not loaded")
+	}
+	selectedProject := ss.configManager.GetSelectedProject()
+
+	if refreshRemote {
+		if err := ss.refreshRemoteFolderSizes(remoteConfig, projectConfig); err != nil {
+			return nil, err
+		}
+	}
+	states := ss.configManager.folderState.get(selectedProject)
+
+	statuses := make([]FolderStatus, 0, len(projectConfig.Folders))
+	for folderKey, folderConfig := range projectConfig.Folders {
+		state := states[folderKey]
+		status := FolderStatus{
+			FolderKey:       folderKey,
+			RemoteSize:      state.RemoteSize,
+			RemoteFileCount: state.RemoteFileCount,
+			RemoteCheckedAt: state.RemoteCheckedAt,
+			LastPush:        state.LastPush,
+			LastPull:        state.LastPull,
+			DirtyState:      state.DirtyState,
+			DirtyCheckedAt:  state.DirtyCheckedAt,
+		}
+		if status.DirtyState == "" {
+			status.DirtyState = DirtyStateUnknown
+		}
+
+		fullLocalPath, err := resolveFolderLocalPath(remoteConfig.LocalPath, folderKey, folderConfig)
+		if err != nil {
+			status.Error = err.Error()
+			statuses = append(statuses, status)
+			continue
+		}
+		if _, err := os.Stat(fullLocalPath); err == nil {
+			status.ExistsLocally = true
+			fileCount, size, err := measureLocalDir(fullLocalPath)
+			if err != nil {
+				status.Error = err.Error()
+			}
+			status.LocalFileCount = fileCount
+			status.LocalSize = size
+		} else if !os.IsNotExist(err) {
+			status.Error = fmt.Sprintf("error accessing local path %s: %v", fullLocalPath, err)
+		}
+		statuses = append(statuses, status)
+	}
+
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].FolderKey < statuses[j].FolderKey
+	})
+	return statuses, nil
+}
+
+// refreshRemoteFolderSizes lists the whole remote once and caches the size and file count of every
+// registered folder.
+func (ss *SyncService) refreshRemoteFolderSizes(remoteConfig *RemoteConfig, projectConfig *ProjectConfig) error {
+	remoteFiles, err := rcloneListFiles(remoteConfig.remoteRoot())
+	if err != nil {
+		return fmt.Errorf("failed to list remote project: %v", err)
+	}
+
+	registeredPaths := make(map[string]string, len(projectConfig.Folders))
+	folderKeys := make([]string, 0, len(projectConfig.Folders))
+	for folderKey, folderConfig := range projectConfig.Folders {
+		registeredPaths[folderKey] = normalizePath(folderConfig.RemotePath)
+		folderKeys = append(folderKeys, folderKey)
+	}
+
+	totals := make(map[string]*remoteFolderTotals)
+	for _, file := range remoteFiles {
+		if file.IsDir {
+			continue
+		}
+		if folderKey, found := matchRegisteredFolder(normalizePath(file.Path), registeredPaths); found {
+			if totals[folderKey] == nil {
+				totals[folderKey] = &remoteFolderTotals{}
+			}
+			totals[folderKey].add(file)
+		}
+	}
+
+	now := time.Now().UTC().Format(time.RFC3339)
+	ss.configManager.folderState.update(ss.configManager.GetSelectedProject(), folderKeys, func(folderKey string, state *folderState) {
+		state.RemoteSize = 0
+		state.RemoteFileCount = 0
+		if folderTotals, found := totals[folderKey]; found {
+			state.RemoteSize = folderTotals.size
+			state.RemoteFileCount = folderTotals.fileCount
+		}
+		state.RemoteCheckedAt = now
+	})
+	return nil
+}
@@ -0,0 +1,35 @@
+package backend
+
+import "testing"
+
+func TestFolderStateStoreRename(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	store := newFolderStateStore()
+	store.update("demo", []string{"assets", "renders"}, func(folderKey string, state *folderState) {
+		state.LastPush = "2026-01-02T03:04:05Z"
+		state.RemoteFileCount = len(folderKey)
+	})
+
+	store.rename("demo", "assets", "textures")
+	states := store.get("demo")
+	if _, exists := states["assets"]; exists {
+		t.Errorf("the old key is still in the store")
+	}
+	if state := states["textures"]; state.LastPush != "2026-01-02T03:04:05Z" || state.RemoteFileCount != len("assets") {
+		t.Errorf("renamed state = %+v, want the state of the old key", state)
+	}
+
+	// A folder without any state, or a rename to the same key, changes nothing
+	store.rename("demo", "missing", "other")
+	store.rename("demo", "renders", "renders")
+	states = store.get("demo")
+	if len(states) != 2 || states["renders"].RemoteFileCount != len("renders") {
+		t.Errorf("states = %+v, want textures and renders unchanged", states)
+	}
+
+	// The rename is saved to disk
+	reloaded := newFolderStateStore()
+	if _, exists := reloaded.get("demo")["textures"]; !exists {
+		t.Errorf("the renamed state was not saved")
+	}
+}
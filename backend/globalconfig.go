@@ -1,20 +1,132 @@
 package backend
 
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
 type GlobalConfig struct {
 	SelectedProject string                  `json:"selected_project"`
+	CredentialStore string                  `json:"credential_store"` // "keyring" (default) or "file"
 	Remotes         map[string]RemoteConfig `json:"remotes"`
+	BandwidthLimit  BandwidthLimit          `json:"bandwidth_limit"` // Applies to projects without their own limit
 }
 
 type RemoteConfig struct {
+	RemoteName      string          `json:"remote_name"`
+	BucketName      string          `json:"bucket_name"`
+	Type            string          `json:"type"`
+	CredentialRef   string          `json:"credential_ref"` // Reference to the account and key in the credential store
+	LocalPath       string          `json:"local_path"`
+	FullBackupPath  string          `json:"full_backup_path"`
+	Encrypted       bool            `json:"encrypted"`                 // Wrap the bucket in an rclone crypt remote
+	CryptRef        string          `json:"crypt_ref"`                 // Reference to the crypt passwords in the credential store
+	BandwidthLimit  *BandwidthLimit `json:"bandwidth_limit,omitempty"` // Replaces the global limit; nil to use it
+	TransferProfile TransferProfile `json:"transfer_profile"`
+}
+
+// cryptRemoteName returns the name of the crypt remote wrapping an encrypted project's bucket.
+func (rc *RemoteConfig) cryptRemoteName() string {
+	return rc.RemoteName + "-crypt"
+}
+
+// remoteRoot returns the rclone fs path of the project root. For encrypted projects this is the
+// crypt remote, so every caller works with plaintext names while the bucket stores ciphertext.
+// sync.json lives inside the crypt layer as well, so folder names and descriptions are never
+// stored in the clear.
+func (rc *RemoteConfig) remoteRoot() string {
+	if rc.Encrypted {
+		return rc.cryptRemoteName() + ":"
+	}
+	return fmt.Sprintf("%s:%s", rc.RemoteName, rc.BucketName)
+}
+
+// remotePath returns the rclone fs path of the given path relative to the project root.
+func (rc *RemoteConfig) remotePath(path string) string {
+	root := rc.remoteRoot()
+	if strings.HasSuffix(root, ":") {
+		return root + path
+	}
+	return root + "/" + path
+}
+
+// legacyRemoteSecrets holds the plaintext secrets that older config.json files stored inline.
+// It is only used to migrate them into the credential store.
+type legacyRemoteSecrets struct {
+	Account string `json:"account"`
+	Key     string `json:"key"`
+}
+
+type legacyGlobalSecrets struct {
+	Remotes map[string]legacyRemoteSecrets `json:"remotes"`
+}
+
+func (gc *GlobalConfig) ToJSON() (string, error) {
+	return MarshalToJSON(gc)
+}
+
+// Clone returns a copy of the global config that can be modified without affecting the original.
+func (gc *GlobalConfig) Clone() *GlobalConfig {
+	clone := *gc
+	clone.Remotes = make(map[string]RemoteConfig, len(gc.Remotes))
+	for project, remote := range gc.Remotes {
+		remote.BandwidthLimit = remote.BandwidthLimit.clone()
+		clone.Remotes[project] = remote
+	}
+	clone.BandwidthLimit = *gc.BandwidthLimit.clone()
+	return &clone
+}
+
+// ProjectSettings are the user-editable, non-secret settings of a project's remote.
+type ProjectSettings struct {
 	RemoteName     string `json:"remote_name"`
 	BucketName     string `json:"bucket_name"`
 	Type           string `json:"type"`
-	Account        string `json:"account"`
-	Key            string `json:"key"`
 	LocalPath      string `json:"local_path"`
 	FullBackupPath string `json:"full_backup_path"`
 }
 
-func (gc *GlobalConfig) ToJSON() (string, error) {
-	return MarshalToJSON(gc)
+// ProjectSummary describes one configured project for the frontend. It never carries credentials.
+type ProjectSummary struct {
+	Name string `json:"name"`
+	ProjectSettings
+	Encrypted       bool            `json:"encrypted"`
+	BandwidthLimit  *BandwidthLimit `json:"bandwidth_limit"` // nil when the project uses the global limit
+	TransferProfile TransferProfile `json:"transfer_profile"`
+}
+
+// GlobalConfigView is the redacted view of the GlobalConfig that is sent to the frontend.
+type GlobalConfigView struct {
+	SelectedProject string           `json:"selected_project"`
+	Projects        []ProjectSummary `json:"projects"` // Sorted by name
+	BandwidthLimit  BandwidthLimit   `json:"bandwidth_limit"`
+}
+
+// View builds the redacted view of the global config.
+func (gc *GlobalConfig) View() GlobalConfigView {
+	view := GlobalConfigView{
+		SelectedProject: gc.SelectedProject,
+		Projects:        []ProjectSummary{},
+		BandwidthLimit:  *gc.BandwidthLimit.clone(),
+	}
+	for project, remote := range gc.Remotes {
+		view.Projects = append(view.Projects, ProjectSummary{
+			Name: project,
+			ProjectSettings: ProjectSettings{
+				RemoteName:     remote.RemoteName,
+				BucketName:     remote.BucketName,
+				Type:           remote.Type,
+				LocalPath:      remote.LocalPath,
+				FullBackupPath: remote.FullBackupPath,
+			},
+			Encrypted:       remote.Encrypted,
+			BandwidthLimit:  remote.BandwidthLimit.clone(),
+			TransferProfile: remote.TransferProfile,
+		})
+	}
+	sort.Slice(view.Projects, func(i, j int) bool {
+		return view.Projects[i].Name < view.Projects[j].Name
+	})
+	return view
 }
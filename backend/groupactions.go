@@ -0,0 +1,188 @@
+package backend
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"sync"
+)
+
+// GroupChangeSummary rolls up change detection for one group. The counts include every folder in
+// the group's descendant groups.
+type GroupChangeSummary struct {
+	GroupKey           string   `json:"group_key"`
+	ParentGroup        string   `json:"parent_group"`
+	FolderCount        int      `json:"folder_count"`         // Registered folders
+	LocalFolderCount   int      `json:"local_folder_count"`   // Folders checked out locally, which are the ones checked for changes
+	ChangedFolderCount int      `json:"changed_folder_count"` // Local folders that differ from the remote
+	ChangedFolders     []string `json:"changed_folders"`      // Changed folders directly in this group
+	Errors             []string `json:"errors"`
+}
+
+// groupFolders returns the sorted keys of every folder in the group and its descendant groups.
+func groupFolders(projectConfig *ProjectConfig, groupKey string) ([]string, error) {
+	if _, exists := projectConfig.Groups[groupKey]; !exists {
+		return nil, fmt.Errorf("group '%s' does not exist", groupKey)
+	}
+	groups := groupWithDescendants(projectConfig, groupKey)
+	folderKeys := []string{}
+	for folderKey, folderConfig := range projectConfig.Folders {
+		if groups[folderConfig.Group] {
+			folderKeys = append(folderKeys, folderKey)
+		}
+	}
+	sort.Strings(folderKeys)
+	return folderKeys, nil
+}
+
+// groupActionFolders returns the folders a group-level action applies to. Actions that need a
+// local copy only apply to the folders that are checked out; the rest are skipped, not failed.
+func (ss *SyncService) groupActionFolders(groupKey string, action RcloneAction) ([]string, error) {
+	projectConfig := ss.configManager.GetProjectConfig()
+	if projectConfig == nil {
+		return nil, fmt.Errorf("project configuration is not loaded")
+	}
+	remoteConfig := ss.configManager.GetSelectedProjectRemoteConfig()
+	if remoteConfig == nil {
+		return nil, fmt.Errorf("selected project's remote configuration is not available")
+	}
+	folderKeys, err := groupFolders(projectConfig, groupKey)
+	if err != nil {
+		return nil, err
+	}
+	if IsFolderOptional(action) {
+		return folderKeys, nil
+	}
+
+	localFolders := []string{}
+	for _, folderKey := range folderKeys {
+		fullLocalPath, err := resolveFolderLocalPath(remoteConfig.LocalPath, folderKey, projectConfig.Folders[folderKey])
+		if err != nil {
+			return nil, err
+		}
+		if _, err := os.Stat(fullLocalPath); err == nil {
+			localFolders = append(localFolders, folderKey)
+		}
+	}
+	return localFolders, nil
+}
+
+// ExecuteGroupAction runs the action on every folder in the group and its descendant groups.
+func (ss *SyncService) ExecuteGroupAction(groupKey string, action RcloneAction, dry bool) []RcloneActionOutput {
+	targetFolders, err := ss.groupActionFolders(groupKey, action)
+	if err != nil {
+		return []RcloneActionOutput{actionErrorOutput(groupKey, err)}
+	}
+	return ss.ExecuteRcloneAction(targetFolders, action, dry)
+}
+
+// ExecuteGroupActionAsync runs the action on every folder in the group and its descendant groups in
+// the background, emitting the same events as ExecuteRcloneActionAsync.
+func (ss *SyncService) ExecuteGroupActionAsync(taskID string, groupKey string, action RcloneAction, dry bool) error {
+	targetFolders, err := ss.groupActionFolders(groupKey, action)
+	if err != nil {
+		go func() {
+			emitEvent(EventTaskFolderComplete, newTaskFolderCompletePayload(taskID, actionErrorOutput(groupKey, err)))
+			emitEvent(EventTaskComplete, TaskCompletePayload{TaskID: taskID})
+		}()
+		return nil
+	}
+	return ss.ExecuteRcloneActionAsync(taskID, targetFolders, "", action, dry)
+}
+
+// DetectGroupChanges checks every checked-out folder in the group and its descendant groups for
+// changes, and returns a summary for the group and each descendant group with the counts rolled up
+// the hierarchy. The summaries are sorted by group key.
+func (ss *SyncService) DetectGroupChanges(groupKey string) ([]GroupChangeSummary, error) {
+	projectConfig := ss.configManager.GetProjectConfig()
+	if projectConfig == nil {
+		return nil, fmt.Errorf("project configuration is not loaded")
+	}
+	remoteConfig := ss.configManager.GetSelectedProjectRemoteConfig()
+	if remoteConfig == nil {
+		return nil, fmt.Errorf("selected project's remote configuration is not available")
+	}
+	if _, exists := projectConfig.Groups[groupKey]; !exists {
+		return nil, fmt.Errorf("group '%s' does not exist", groupKey)
+	}
+
+	summaries := make(map[string]*GroupChangeSummary)
+	for subgroupKey := range groupWithDescendants(projectConfig, groupKey) {
+		summaries[subgroupKey] = &GroupChangeSummary{
+			GroupKey:       subgroupKey,
+			ParentGroup:    projectConfig.Groups[subgroupKey].ParentGroup,
+			ChangedFolders: []string{},
+			Errors:         []string{},
+		}
+	}
+
+	// Check the local folders in parallel
+	type folderResult struct {
+		folderKey  string
+		isLocal    bool
+		hasChanges bool
+		err        error
+	}
+	var wg sync.WaitGroup
+	results := make(chan folderResult, len(projectConfig.Folders))
+	for folderKey, folderConfig := range projectConfig.Folders {
+		if summaries[folderConfig.Group] == nil {
+			continue
+		}
+		wg.Add(1)
+		go func(folderKey string, folderConfig FolderConfig) {
+			defer wg.Done()
+			fullLocalPath, fullRemotePath, err := resolveFolderPaths(remoteConfig, folderKey, folderConfig)
+			if err != nil {
+				results <- folderResult{folderKey: folderKey, err: err}
+				return
+			}
+			if _, err := os.Stat(fullLocalPath); err != nil {
+				results <- folderResult{folderKey: folderKey}
+				return
+			}
+			hasChanges, err := RcloneHasChanges(fullLocalPath, fullRemotePath)
+			if err == nil {
+				ss.recordDirtyState(folderKey, hasChanges)
+			}
+			results <- folderResult{folderKey: folderKey, isLocal: true, hasChanges: hasChanges, err: err}
+		}(folderKey, folderConfig)
+	}
+	wg.Wait()
+	close(results)
+
+	// Roll each folder's result up from its own group to the requested group
+	for result := range results {
+		folderGroup := projectConfig.Folders[result.folderKey].Group
+		if result.hasChanges {
+			summaries[folderGroup].ChangedFolders = append(summaries[folderGroup].ChangedFolders, result.folderKey)
+		}
+		for current := folderGroup; summaries[current] != nil; current = summaries[current].ParentGroup {
+			summary := summaries[current]
+			summary.FolderCount++
+			if result.isLocal {
+				summary.LocalFolderCount++
+			}
+			if result.hasChanges {
+				summary.ChangedFolderCount++
+			}
+			if result.err != nil {
+				summary.Errors = append(summary.Errors, fmt.Sprintf("%s: %v", result.folderKey, result.err))
+			}
+			if current == groupKey {
+				break
+			}
+		}
+	}
+
+	groupSummaries := make([]GroupChangeSummary, 0, len(summaries))
+	for _, summary := range summaries {
+		sort.Strings(summary.ChangedFolders)
+		sort.Strings(summary.Errors)
+		groupSummaries = append(groupSummaries, *summary)
+	}
+	sort.Slice(groupSummaries, func(i, j int) bool {
+		return groupSummaries[i].GroupKey < groupSummaries[j].GroupKey
+	})
+	return groupSummaries, nil
+}
@@ -0,0 +1,242 @@
+package backend
+
+import (
+	"fmt"
+	"sort"
+)
+
+// GroupTreeNode is a group with its folders and child groups, all in display order.
+type GroupTreeNode struct {
+	Key         string          `json:"key"`
+	Name        string          `json:"name"`
+	ParentGroup string          `json:"parent_group"`
+	SortOrder   int             `json:"sort_order"`
+	Folders     []string        `json:"folders"`
+	Children    []GroupTreeNode `json:"children"`
+}
+
+// sortedChildGroups returns the keys of the parent's child groups in display order: by sort order,
+// then by name, then by key. Groups whose parent doesn't exist are shown at the top level.
+func sortedChildGroups(projectConfig *ProjectConfig, parentKey string) []string {
+	childKeys := []string{}
+	for groupKey, groupConfig := range projectConfig.Groups {
+		parent := groupConfig.ParentGroup
+		if _, parentExists := projectConfig.Groups[parent]; !parentExists {
+			parent = ""
+		}
+		if parent == parentKey {
+			childKeys = append(childKeys, groupKey)
+		}
+	}
+	sort.Slice(childKeys, func(i, j int) bool {
+		a, b := projectConfig.Groups[childKeys[i]], projectConfig.Groups[childKeys[j]]
+		if a.SortOrder != b.SortOrder {
+			return a.SortOrder < b.SortOrder
+		}
+		if a.Name != b.Name {
+			return a.Name < b.Name
+		}
+		return childKeys[i] < childKeys[j]
+	})
+	return childKeys
+}
+
+// sortedGroupFolders returns the keys of the group's folders in display order: by sort order, then by key.
+func sortedGroupFolders(projectConfig *ProjectConfig, groupKey string) []string {
+	folderKeys := []string{}
+	for folderKey, folderConfig := range projectConfig.Folders {
+		if folderConfig.Group == groupKey {
+			folderKeys = append(folderKeys, folderKey)
+		}
+	}
+	sort.Slice(folderKeys, func(i, j int) bool {
+		a, b := projectConfig.Folders[folderKeys[i]], projectConfig.Folders[folderKeys[j]]
+		if a.SortOrder != b.SortOrder {
+			return a.SortOrder < b.SortOrder
+		}
+		return folderKeys[i] < folderKeys[j]
+	})
+	return folderKeys
+}
+
+// applyGroupOrder sets the sort order of each group to its position in the list.
+func applyGroupOrder(projectConfig *ProjectConfig, orderedKeys []string) {
+	for index, groupKey := range orderedKeys {
+		groupConfig := projectConfig.Groups[groupKey]
+		groupConfig.SortOrder = index
+		projectConfig.Groups[groupKey] = groupConfig
+	}
+}
+
+// applyFolderOrder sets the sort order of each folder to its position in the list.
+func applyFolderOrder(projectConfig *ProjectConfig, orderedKeys []string) {
+	for index, folderKey := range orderedKeys {
+		folderConfig := projectConfig.Folders[folderKey]
+		folderConfig.SortOrder = index
+		projectConfig.Folders[folderKey] = folderConfig
+	}
+}
+
+// insertAt inserts the key into the list at the given index, clamped to the list's bounds. A
+// negative index appends it.
+func insertAt(keys []string, key string, index int) []string {
+	if index < 0 || index > len(keys) {
+		index = len(keys)
+	}
+	keys = append(keys, "")
+	copy(keys[index+1:], keys[index:])
+	keys[index] = key
+	return keys
+}
+
+// withoutKey returns the list without the given key.
+func withoutKey(keys []string, key string) []string {
+	filtered := make([]string, 0, len(keys))
+	for _, k := range keys {
+		if k != key {
+			filtered = append(filtered, k)
+		}
+	}
+	return filtered
+}
+
+// keyIndex returns the position of the key in the list, or -1 if it isn't there.
+func keyIndex(keys []string, key string) int {
+	for i, k := range keys {
+		if k == key {
+			return i
+		}
+	}
+	return -1
+}
+
+// placeGroup moves an existing group under the new parent at the given position, and keeps the
+// sort orders of both its old and new siblings dense. A negative index places it last.
+func placeGroup(projectConfig *ProjectConfig, groupKey string, newParent string, index int) {
+	oldParent := projectConfig.Groups[groupKey].ParentGroup
+	groupConfig := projectConfig.Groups[groupKey]
+	groupConfig.ParentGroup = newParent
+	projectConfig.Groups[groupKey] = groupConfig
+
+	if oldParent != newParent {
+		applyGroupOrder(projectConfig, withoutKey(sortedChildGroups(projectConfig, oldParent), groupKey))
+	}
+	siblings := withoutKey(sortedChildGroups(projectConfig, newParent), groupKey)
+	applyGroupOrder(projectConfig, insertAt(siblings, groupKey, index))
+}
+
+// placeFolder moves an existing folder into the group at the given position, and keeps the sort
+// orders of both its old and new group dense. A negative index places it last.
+func placeFolder(projectConfig *ProjectConfig, folderKey string, oldGroup string, newGroup string, index int) {
+	if oldGroup != newGroup {
+		applyFolderOrder(projectConfig, withoutKey(sortedGroupFolders(projectConfig, oldGroup), folderKey))
+	}
+	siblings := withoutKey(sortedGroupFolders(projectConfig, newGroup), folderKey)
+	applyFolderOrder(projectConfig, insertAt(siblings, folderKey, index))
+}
+
+// buildGroupTree returns the child groups of the parent as a sorted tree.
+func buildGroupTree(projectConfig *ProjectConfig, parentKey string, visited map[string]bool) []GroupTreeNode {
+	nodes := []GroupTreeNode{}
+	for _, groupKey := range sortedChildGroups(projectConfig, parentKey) {
+		if visited[groupKey] {
+			continue
+		}
+		visited[groupKey] = true
+		groupConfig := projectConfig.Groups[groupKey]
+		nodes = append(nodes, GroupTreeNode{
+			Key:         groupKey,
+			Name:        groupConfig.Name,
+			ParentGroup: groupConfig.ParentGroup,
+			SortOrder:   groupConfig.SortOrder,
+			Folders:     sortedGroupFolders(projectConfig, groupKey),
+			Children:    buildGroupTree(projectConfig, groupKey, visited),
+		})
+	}
+	return nodes
+}
+
+// ReorderGroups sets the order of the parent's child groups. orderedKeys must list every child
+// group of the parent exactly once. Use an empty parent for the top-level groups.
+func (fs *FolderService) ReorderGroups(parentKey string, orderedKeys []string) (ProjectConfig, error) {
+	return fs.updateProjectConfig(func(projectConfig *ProjectConfig, projectRoot string) error {
+		return applyReorderGroups(projectConfig, parentKey, orderedKeys)
+	})
+}
+
+// applyReorderGroups sets the order of the parent's child groups in the project config.
+func applyReorderGroups(projectConfig *ProjectConfig, parentKey string, orderedKeys []string) error {
+	if parentKey != "" {
+		if _, exists := projectConfig.Groups[parentKey]; !exists {
+			return fmt.Errorf("group '%s' does not exist", parentKey)
+		}
+	}
+	if err := checkSameKeys(sortedChildGroups(projectConfig, parentKey), orderedKeys, "child groups"); err != nil {
+		return err
+	}
+	applyGroupOrder(projectConfig, orderedKeys)
+	return nil
+}
+
+// MoveGroup moves a group, with everything below it, under a new parent at the given position
+// among its new siblings. Use an empty parent to move it to the top level.
+func (fs *FolderService) MoveGroup(groupKey string, newParent string, index int) (ProjectConfig, error) {
+	return fs.updateProjectConfig(func(projectConfig *ProjectConfig, projectRoot string) error {
+		return applyMoveGroup(projectConfig, groupKey, newParent, index)
+	})
+}
+
+// applyMoveGroup moves a group under a new parent in the project config.
+func applyMoveGroup(projectConfig *ProjectConfig, groupKey string, newParent string, index int) error {
+	if _, exists := projectConfig.Groups[groupKey]; !exists {
+		return fmt.Errorf("group '%s' does not exist", groupKey)
+	}
+	if newParent != "" {
+		if _, exists := projectConfig.Groups[newParent]; !exists {
+			return fmt.Errorf("parent group '%s' does not exist", newParent)
+		}
+		if newParent == groupKey || wouldCreateCircularReference(projectConfig, groupKey, newParent) {
+			return fmt.Errorf("this would create a circular group reference")
+		}
+	}
+	placeGroup(projectConfig, groupKey, newParent, index)
+	return nil
+}
+
+// ReorderFolders sets the order of the folders inside a group. orderedKeys must list every folder
+// in the group exactly once.
+func (fs *FolderService) ReorderFolders(groupKey string, orderedKeys []string) (ProjectConfig, error) {
+	return fs.updateProjectConfig(func(projectConfig *ProjectConfig, projectRoot string) error {
+		return applyReorderFolders(projectConfig, groupKey, orderedKeys)
+	})
+}
+
+// applyReorderFolders sets the order of a group's folders in the project config.
+func applyReorderFolders(projectConfig *ProjectConfig, groupKey string, orderedKeys []string) error {
+	if _, exists := projectConfig.Groups[groupKey]; !exists {
+		return fmt.Errorf("group '%s' does not exist", groupKey)
+	}
+	if err := checkSameKeys(sortedGroupFolders(projectConfig, groupKey), orderedKeys, "folders"); err != nil {
+		return err
+	}
+	applyFolderOrder(projectConfig, orderedKeys)
+	return nil
+}
+
+// checkSameKeys returns an error unless orderedKeys lists exactly the expected keys, once each.
+func checkSameKeys(expected []string, orderedKeys []string, what string) error {
+	if len(orderedKeys) != len(expected) {
+		return fmt.Errorf("expected %d %s in the new order, got %d", len(expected), what, len(orderedKeys))
+	}
+	remaining := make(map[string]bool, len(expected))
+	for _, key := range expected {
+		remaining[key] = true
+	}
+	for _, key := range orderedKeys {
+		if !remaining[key] {
+			return fmt.Errorf("'%s' is not one of the %s, or is listed twice", key, what)
+		}
+		delete(remaining, key)
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+package backend
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestInsertAt(t *testing.T) {
+	tests := []struct {
+		name  string
+		keys  []string
+		index int
+		want  []string
+	}{
+		{"empty list", []string{}, 0, []string{"x"}},
+		{"first", []string{"a", "b"}, 0, []string{"x", "a", "b"}},
+		{"middle", []string{"a", "b"}, 1, []string{"a", "x", "b"}},
+		{"end", []string{"a", "b"}, 2, []string{"a", "b", "x"}},
+		{"past the end", []string{"a", "b"}, 5, []string{"a", "b", "x"}},
+		{"minus one is last", []string{"a", "b"}, -1, []string{"a", "b", "x"}},
+		{"any negative is last", []string{"a", "b"}, -7, []string{"a", "b", "x"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := insertAt(append([]string(nil), tt.keys...), "x", tt.index); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("insertAt(%v, x, %d) = %v, want %v", tt.keys, tt.index, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPlaceGroup(t *testing.T) {
+	tests := []struct {
+		name        string
+		groupKey    string
+		newParent   string
+		index       int
+		wantTop     []string
+		wantInShots []string
+	}{
+		{"reorder to first", "renders", "", 0, []string{"renders", "assets", "shots"}, []string{"seq01", "seq02"}},
+		{"reorder to last", "assets", "", -1, []string{"shots", "renders", "assets"}, []string{"seq01", "seq02"}},
+		{"index past the end", "assets", "", 10, []string{"shots", "renders", "assets"}, []string{"seq01", "seq02"}},
+		{"move under another parent", "renders", "shots", 1, []string{"assets", "shots"}, []string{"seq01", "renders", "seq02"}},
+		{"move under another parent last", "renders", "shots", -1, []string{"assets", "shots"}, []string{"seq01", "seq02", "renders"}},
+		{"move to the top level", "seq01", "", 0, []string{"seq01", "assets", "shots", "renders"}, []string{"seq02"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			projectConfig := &ProjectConfig{Groups: map[string]GroupConfig{
+				"assets":  {Name: "Assets", SortOrder: 0},
+				"shots":   {Name: "Shots", SortOrder: 1},
+				"renders": {Name: "Renders", SortOrder: 2},
+				"seq01":   {Name: "seq01", ParentGroup: "shots", SortOrder: 0},
+				"seq02":   {Name: "seq02", ParentGroup: "shots", SortOrder: 1},
+			}}
+			placeGroup(projectConfig, tt.groupKey, tt.newParent, tt.index)
+
+			if got := sortedChildGroups(projectConfig, ""); !reflect.DeepEqual(got, tt.wantTop) {
+				t.Errorf("top-level groups = %v, want %v", got, tt.wantTop)
+			}
+			if got := sortedChildGroups(projectConfig, "shots"); !reflect.DeepEqual(got, tt.wantInShots) {
+				t.Errorf("groups in shots = %v, want %v", got, tt.wantInShots)
+			}
+			// Sort orders stay dense on both sides of a move
+			for _, parentKey := range []string{"", "shots"} {
+				for i, groupKey := range sortedChildGroups(projectConfig, parentKey) {
+					if projectConfig.Groups[groupKey].SortOrder != i {
+						t.Errorf("group %q has sort order %d, want %d", groupKey, projectConfig.Groups[groupKey].SortOrder, i)
+					}
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,82 @@
+package backend
+
+import (
+	"fmt"
+	"os"
+	"sort"
+)
+
+// OffloadResult is the outcome of offloading a single folder.
+type OffloadResult struct {
+	FolderKey     string      `json:"folder_key"`
+	Offloaded     bool        `json:"offloaded"`
+	UnpushedFiles []DiffEntry `json:"unpushed_files"` // Local files that are missing from, or differ on, the remote
+	Error         string      `json:"error"`
+}
+
+// OffloadFolders frees local disk space by deleting the given folders locally, but only once the
+// remote is verified to hold every local file. Each folder's local copy is compared against the
+// remote by size and modification time; if any local file is missing from the remote or differs,
+// the folder is kept and the unpushed files are listed in its result instead.
+func (fs *FolderService) OffloadFolders(targetFolders []string) ([]OffloadResult, error) {
+	projectRemoteConfig, err := fs.getProjectRemoteConfig()
+	if err != nil {
+		return nil, err
+	}
+	projectConfig, err := fs.getProjectConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]OffloadResult, 0, len(targetFolders))
+	for _, folderKey := range targetFolders {
+		result := OffloadResult{FolderKey: folderKey, UnpushedFiles: []DiffEntry{}}
+
+		folderConfig, exists := projectConfig.Folders[folderKey]
+		if !exists {
+			result.Error = fmt.Sprintf("target folder '%s' not found in project configuration", folderKey)
+			results = append(results, result)
+			continue
+		}
+		fullLocalPath, fullRemotePath, err := resolveFolderPaths(projectRemoteConfig, folderKey, folderConfig)
+		if err != nil {
+			result.Error = err.Error()
+			results = append(results, result)
+			continue
+		}
+		if _, err := os.Stat(fullLocalPath); err != nil {
+			result.Error = fmt.Sprintf("folder '%s' is not checked out locally", folderKey)
+			results = append(results, result)
+			continue
+		}
+
+		// Every local file must exist on the remote with the same size and modification time.
+		// Files that only exist on the remote don't matter, since nothing is lost by deleting locally.
+		diff, _, err := diffFiles(fullLocalPath, fullRemotePath)
+		if err != nil {
+			result.Error = fmt.Sprintf("could not verify the remote copy of folder '%s'; nothing was deleted: %v", folderKey, err)
+			results = append(results, result)
+			continue
+		}
+		result.UnpushedFiles = append(result.UnpushedFiles, diff.Additions...)
+		result.UnpushedFiles = append(result.UnpushedFiles, diff.Updates...)
+		if len(result.UnpushedFiles) > 0 {
+			sort.Slice(result.UnpushedFiles, func(i, j int) bool {
+				return result.UnpushedFiles[i].Path < result.UnpushedFiles[j].Path
+			})
+			result.Error = fmt.Sprintf("folder '%s' has %d file(s) that aren't on the remote; push them before offloading", folderKey, len(result.UnpushedFiles))
+			results = append(results, result)
+			continue
+		}
+
+		if err := os.RemoveAll(fullLocalPath); err != nil {
+			result.Error = fmt.Sprintf("failed to delete folder '%s': %v", fullLocalPath, err)
+			results = append(results, result)
+			continue
+		}
+		result.Offloaded = true
+		results = append(results, result)
+	}
+
+	return results, nil
+}
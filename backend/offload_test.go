@@ -0,0 +1,79 @@
+package backend
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// writeTestFile writes a file below dir, creating its parent directories, with the given mod time.
+func writeTestFile(t *testing.T, dir string, relPath string, content string, modTime time.Time) {
+	t.Helper()
+	path := filepath.Join(dir, filepath.FromSlash(relPath))
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chtimes(path, modTime, modTime); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestOffloadFoldersKeepsUnpushedFiles(t *testing.T) {
+	initTestRclone()
+	if err := RcloneCreateRemote("offloadlocal", "local", map[string]string{}); err != nil {
+		t.Fatalf("failed to create local remote: %v", err)
+	}
+
+	bucketDir, localDir := t.TempDir(), t.TempDir()
+	modTime := time.Now().Add(-time.Hour).Truncate(time.Second)
+	for _, dir := range []string{bucketDir, localDir} {
+		writeTestFile(t, dir, "pushed/a.txt", "pushed", modTime)
+		writeTestFile(t, dir, "added/a.txt", "pushed", modTime)
+		writeTestFile(t, dir, "updated/a.txt", "pushed", modTime)
+	}
+	writeTestFile(t, localDir, "added/new.txt", "only local", modTime)
+	writeTestFile(t, localDir, "updated/a.txt", "changed locally", modTime.Add(time.Minute))
+
+	cm := NewConfigManager(&GlobalConfig{
+		SelectedProject: "demo",
+		Remotes: map[string]RemoteConfig{
+			"demo": {RemoteName: "offloadlocal", BucketName: bucketDir, LocalPath: localDir},
+		},
+	}, &ProjectConfig{Folders: map[string]FolderConfig{
+		"pushed":  {LocalPath: "pushed", RemotePath: "pushed"},
+		"added":   {LocalPath: "added", RemotePath: "added"},
+		"updated": {LocalPath: "updated", RemotePath: "updated"},
+	}})
+
+	results, err := NewFolderService(cm).OffloadFolders([]string{"pushed", "added", "updated"})
+	if err != nil {
+		t.Fatalf("OffloadFolders failed: %v", err)
+	}
+
+	tests := []struct {
+		folderKey     string
+		wantOffloaded bool
+		wantUnpushed  string
+	}{
+		{"pushed", true, ""},
+		{"added", false, "new.txt"},
+		{"updated", false, "a.txt"},
+	}
+	for i, tt := range tests {
+		result := results[i]
+		if result.Offloaded != tt.wantOffloaded {
+			t.Errorf("folder %q offloaded = %v, want %v (error: %s)", tt.folderKey, result.Offloaded, tt.wantOffloaded, result.Error)
+		}
+		_, statErr := os.Stat(filepath.Join(localDir, tt.folderKey))
+		if exists := statErr == nil; exists == tt.wantOffloaded {
+			t.Errorf("folder %q exists locally = %v after offloading = %v", tt.folderKey, exists, result.Offloaded)
+		}
+		if tt.wantUnpushed != "" && (len(result.UnpushedFiles) != 1 || result.UnpushedFiles[0].Path != tt.wantUnpushed) {
+			t.Errorf("folder %q unpushed files = %+v, want only %q", tt.folderKey, result.UnpushedFiles, tt.wantUnpushed)
+		}
+	}
+}
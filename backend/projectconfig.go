@@ -1,29 +1,74 @@
 package backend
 
 type ProjectConfig struct {
-	AllowGlobalSync bool                    `json:"allow_global_sync"`
-	Folders         map[string]FolderConfig `json:"folders"`
-	Groups          map[string]GroupConfig  `json:"groups"`
+	AllowGlobalSync bool                       `json:"allow_global_sync"`
+	Folders         map[string]FolderConfig    `json:"folders"`
+	Groups          map[string]GroupConfig     `json:"groups"`
+	Trash           []TrashEntry               `json:"trash,omitempty"`      // Folders deleted from the remote that can still be restored
+	Selections      map[string]SelectionConfig `json:"selections,omitempty"` // Saved, named folder selections
 }
 
 // GroupConfig defines a folder group for organizing folders in the UI
 type GroupConfig struct {
 	Name        string `json:"name"`         // Display name
 	ParentGroup string `json:"parent_group"` // Empty = top-level, otherwise = nested under parent
-	SortOrder   int    `json:"sort_order"`   // For manual ordering (future)
+	SortOrder   int    `json:"sort_order"`   // Position among the groups with the same parent
 }
 
 type FolderConfig struct {
-	RemotePath  string `json:"remote_path"`
-	LocalPath   string `json:"local_path"`
-	Description string `json:"description"`
-	Group       string `json:"group"` // Group key (required for new folders)
+	RemotePath  string   `json:"remote_path"`
+	LocalPath   string   `json:"local_path"`
+	Description string   `json:"description"`
+	Group       string   `json:"group"`          // Group key (required for new folders)
+	Tags        []string `json:"tags,omitempty"` // Free-form tags, for selections that cut across groups
+	SortOrder   int      `json:"sort_order"`     // Position among the folders in the same group
 }
 
+// defaultGroupKey is the group that folders without a group are assigned to.
+const defaultGroupKey = "general"
+
 func (pc *ProjectConfig) ToJSON() (string, error) {
 	return MarshalToJSON(pc)
 }
 
+// Clone returns a deep copy of the project config that can be modified without affecting the original.
+func (pc *ProjectConfig) Clone() *ProjectConfig {
+	clone := *pc
+	clone.Folders = make(map[string]FolderConfig, len(pc.Folders))
+	for key, folder := range pc.Folders {
+		clone.Folders[key] = folder.clone()
+	}
+	clone.Groups = make(map[string]GroupConfig, len(pc.Groups))
+	for key, group := range pc.Groups {
+		clone.Groups[key] = group
+	}
+	clone.Trash = append([]TrashEntry(nil), pc.Trash...)
+	for i := range clone.Trash {
+		clone.Trash[i].FolderConfig = clone.Trash[i].FolderConfig.clone()
+	}
+	if pc.Selections != nil {
+		clone.Selections = make(map[string]SelectionConfig, len(pc.Selections))
+		for key, selection := range pc.Selections {
+			clone.Selections[key] = selection.clone()
+		}
+	}
+	return &clone
+}
+
+// clone returns a copy of the folder config that shares no slices with the original.
+func (fc FolderConfig) clone() FolderConfig {
+	fc.Tags = cloneStrings(fc.Tags)
+	return fc
+}
+
+// cloneStrings copies a string slice, keeping nil and empty slices apart so the JSON is unchanged.
+func cloneStrings(values []string) []string {
+	if values == nil {
+		return nil
+	}
+	return append(make([]string, 0, len(values)), values...)
+}
+
 // InitDefaults ensures Folders and Groups are initialized (never null).
 // Returns true if any initialization was needed.
 func (pc *ProjectConfig) InitDefaults() bool {
@@ -65,7 +110,6 @@ func (pc *ProjectConfig) MigrateToGroups() bool {
 	}
 
 	// Create the default "General" group if it doesn't exist
-	const defaultGroupKey = "general"
 	if _, exists := pc.Groups[defaultGroupKey]; !exists {
 		pc.Groups[defaultGroupKey] = GroupConfig{
 			Name:        "General",
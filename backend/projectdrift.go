@@ -0,0 +1,191 @@
+package backend
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+// RemoteFolderSummary describes what a registered folder holds on the remote.
+type RemoteFolderSummary struct {
+	FolderKey     string `json:"folder_key"`
+	FileCount     int    `json:"file_count"`
+	Size          string `json:"size"`
+	LatestModTime string `json:"latest_mod_time"` // RFC3339, empty if the folder has no files
+}
+
+// ProjectDrift is the result of comparing the whole remote bucket against the registered folders.
+type ProjectDrift struct {
+	UnregisteredRemoteFolders []string                 `json:"unregistered_remote_folders"` // Shallowest remote directories that no registered folder covers
+	UnmappedRemoteFiles       []string                 `json:"unmapped_remote_files"`       // Loose files outside any registered folder or unregistered directory
+	MissingRemoteFolders      []string                 `json:"missing_remote_folders"`      // Registered folders with nothing on the remote
+	RemoteOnlyFolders         []RemoteFolderSummary    `json:"remote_only_folders"`         // Registered folders on the remote that aren't downloaded locally
+	InvalidFolders            []ProjectValidationIssue `json:"invalid_folders"`             // Registered folders whose paths are invalid, so their local copy wasn't checked
+}
+
+// DetectProjectDrift lists the whole remote project and maps every path to its registered folder.
+// It reports remote directories nobody has registered yet, registered folders that are missing on
+// the remote, and what the remote holds for registered folders that aren't downloaded locally.
+func (ss *SyncService) DetectProjectDrift() (ProjectDrift, error) {
+	return ss.configManager.detectProjectDrift()
+}
+
+// detectProjectDrift is shared by SyncService and FolderService, which registers the remote
+// directories it discovers.
+func (cm *ConfigManager) detectProjectDrift() (ProjectDrift, error) {
+	drift := ProjectDrift{
+		UnregisteredRemoteFolders: []string{},
+		UnmappedRemoteFiles:       []string{},
+		MissingRemoteFolders:      []string{},
+		RemoteOnlyFolders:         []RemoteFolderSummary{},
+		InvalidFolders:            []ProjectValidationIssue{},
+	}
+
+	remoteConfig := cm.GetSelectedProjectRemoteConfig()
+	if remoteConfig == nil {
+		return drift, errors.New("selected project's remote configuration is not available")
+	}
+	projectConfig := cm.GetProjectConfig()
+	if projectConfig == nil {
+		return drift, errors.New("project configuration is not loaded")
+	}
+
+	remoteFiles, err := rcloneListFiles(remoteConfig.remoteRoot())
+	if err != nil {
+		return drift, fmt.Errorf("failed to list remote project: %v", err)
+	}
+
+	// Normalize the registered remote paths once
+	registeredPaths := make(map[string]string, len(projectConfig.Folders))
+	for folderKey, folderConfig := range projectConfig.Folders {
+		registeredPaths[folderKey] = normalizePath(folderConfig.RemotePath)
+	}
+
+	// Map every remote file to its registered folder, or to the directory it should be registered under
+	summaries := make(map[string]*remoteFolderTotals)
+	unregisteredFolders := make(map[string]bool)
+	for _, file := range remoteFiles {
+		if file.IsDir {
+			continue
+		}
+		filePath := normalizePath(file.Path)
+		if filePath == "sync.json" || isPathWithin(filePath, trashDirName) {
+			continue
+		}
+
+		if folderKey, found := matchRegisteredFolder(filePath, registeredPaths); found {
+			totals, exists := summaries[folderKey]
+			if !exists {
+				totals = &remoteFolderTotals{}
+				summaries[folderKey] = totals
+			}
+			totals.add(file)
+			continue
+		}
+
+		if unregisteredDir := findUnregisteredDir(filePath, registeredPaths); unregisteredDir != "" {
+			unregisteredFolders[unregisteredDir] = true
+		} else {
+			drift.UnmappedRemoteFiles = append(drift.UnmappedRemoteFiles, filePath)
+		}
+	}
+
+	for unregisteredDir := range unregisteredFolders {
+		drift.UnregisteredRemoteFolders = append(drift.UnregisteredRemoteFolders, unregisteredDir)
+	}
+
+	// Compare the registered folders against the remote and the local disk
+	for folderKey, folderConfig := range projectConfig.Folders {
+		totals, onRemote := summaries[folderKey]
+		if !onRemote {
+			drift.MissingRemoteFolders = append(drift.MissingRemoteFolders, folderKey)
+			continue
+		}
+		fullLocalPath, pathErr := resolveFolderLocalPath(remoteConfig.LocalPath, folderKey, folderConfig)
+		if pathErr != nil {
+			// Report the folder and go on, so one bad entry doesn't hide the rest of the drift
+			drift.InvalidFolders = append(drift.InvalidFolders, ProjectValidationIssue{Folders: []string{folderKey}, Message: pathErr.Error()})
+			continue
+		}
+		if _, statErr := os.Stat(fullLocalPath); os.IsNotExist(statErr) {
+			drift.RemoteOnlyFolders = append(drift.RemoteOnlyFolders, totals.summary(folderKey))
+		}
+	}
+
+	sort.Strings(drift.UnregisteredRemoteFolders)
+	sort.Strings(drift.UnmappedRemoteFiles)
+	sort.Strings(drift.MissingRemoteFolders)
+	sort.Slice(drift.RemoteOnlyFolders, func(i, j int) bool {
+		return drift.RemoteOnlyFolders[i].FolderKey < drift.RemoteOnlyFolders[j].FolderKey
+	})
+	sort.Slice(drift.InvalidFolders, func(i, j int) bool {
+		return drift.InvalidFolders[i].Folders[0] < drift.InvalidFolders[j].Folders[0]
+	})
+
+	return drift, nil
+}
+
+// remoteFolderTotals accumulates the remote files of one registered folder.
+type remoteFolderTotals struct {
+	fileCount     int
+	size          int64
+	latestModTime time.Time
+}
+
+func (rft *remoteFolderTotals) add(file fileInfo) {
+	rft.fileCount++
+	rft.size += file.Size
+	if modTime, err := time.Parse(time.RFC3339Nano, file.ModTime); err == nil && modTime.After(rft.latestModTime) {
+		rft.latestModTime = modTime
+	}
+}
+
+func (rft *remoteFolderTotals) summary(folderKey string) RemoteFolderSummary {
+	summary := RemoteFolderSummary{
+		FolderKey: folderKey,
+		FileCount: rft.fileCount,
+		Size:      formatSize(rft.size),
+	}
+	if !rft.latestModTime.IsZero() {
+		summary.LatestModTime = rft.latestModTime.Format(time.RFC3339)
+	}
+	return summary
+}
+
+// matchRegisteredFolder returns the key of the registered folder containing the given path. If
+// registered folders are nested, the deepest one wins.
+func matchRegisteredFolder(path string, registeredPaths map[string]string) (string, bool) {
+	matchedKey := ""
+	matchedPath := ""
+	for folderKey, registeredPath := range registeredPaths {
+		if isPathWithin(path, registeredPath) && len(registeredPath) > len(matchedPath) {
+			matchedKey = folderKey
+			matchedPath = registeredPath
+		}
+	}
+	return matchedKey, matchedKey != ""
+}
+
+// findUnregisteredDir returns the shallowest directory of an unregistered file path that doesn't
+// contain any registered folder. Returns an empty string when the file sits directly in the
+// project root or in a directory that holds registered folders.
+func findUnregisteredDir(path string, registeredPaths map[string]string) string {
+	parts := strings.Split(path, "/")
+	for depth := 1; depth < len(parts); depth++ {
+		dir := strings.Join(parts[:depth], "/")
+		containsRegistered := false
+		for _, registeredPath := range registeredPaths {
+			if isPathWithin(registeredPath, dir) {
+				containsRegistered = true
+				break
+			}
+		}
+		if !containsRegistered {
+			return dir
+		}
+	}
+	return ""
+}
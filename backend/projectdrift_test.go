@@ -0,0 +1,49 @@
+package backend
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDetectProjectDriftSkipsInvalidFolders(t *testing.T) {
+	initTestRclone()
+	if err := RcloneCreateRemote("driftlocal", "local", map[string]string{}); err != nil {
+		t.Fatalf("failed to create local remote: %v", err)
+	}
+
+	bucketDir := t.TempDir()
+	for _, file := range []string{"good/a.txt", "bad/b.txt", "loose/c.txt"} {
+		path := filepath.Join(bucketDir, filepath.FromSlash(file))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(file), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	cm := NewConfigManager(&GlobalConfig{
+		SelectedProject: "demo",
+		Remotes: map[string]RemoteConfig{
+			"demo": {RemoteName: "driftlocal", BucketName: bucketDir, LocalPath: t.TempDir()},
+		},
+	}, &ProjectConfig{Folders: map[string]FolderConfig{
+		"good": {LocalPath: "good", RemotePath: "good"},
+		// A hand-edited entry whose local path escapes the project root
+		"bad": {LocalPath: "../bad", RemotePath: "bad"},
+	}})
+
+	drift, err := cm.detectProjectDrift()
+	if err != nil {
+		t.Fatalf("detectProjectDrift failed: %v", err)
+	}
+	if len(drift.InvalidFolders) != 1 || drift.InvalidFolders[0].Folders[0] != "bad" {
+		t.Errorf("invalid folders = %+v, want only 'bad'", drift.InvalidFolders)
+	}
+	if len(drift.RemoteOnlyFolders) != 1 || drift.RemoteOnlyFolders[0].FolderKey != "good" {
+		t.Errorf("remote-only folders = %+v, want only 'good'", drift.RemoteOnlyFolders)
+	}
+	if len(drift.UnregisteredRemoteFolders) != 1 || drift.UnregisteredRemoteFolders[0] != "loose" {
+		t.Errorf("unregistered remote folders = %v, want [loose]", drift.UnregisteredRemoteFolders)
+	}
+}
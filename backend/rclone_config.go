@@ -0,0 +1,112 @@
+package backend
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/rclone/rclone/fs/config"
+)
+
+// memoryConfigStorage is an rclone config storage that only lives in memory. The app's remotes
+// are created in it with config/create from the credential store on every load, so their
+// account keys and crypt passwords are never written to an rclone.conf on disk.
+type memoryConfigStorage struct {
+	sections map[string]map[string]string
+	order    []string // Section names in the order they were created
+	mu       sync.Mutex
+}
+
+var _ config.Storage = (*memoryConfigStorage)(nil)
+
+func newMemoryConfigStorage() *memoryConfigStorage {
+	return &memoryConfigStorage{sections: make(map[string]map[string]string)}
+}
+
+// useMemoryRcloneConfig makes the embedded rclone keep its config in memory instead of reading
+// and writing an rclone.conf. The storage has to be installed while a config path is still set,
+// as rclone ignores new storage once it is in memory-only mode.
+func useMemoryRcloneConfig() {
+	config.SetData(newMemoryConfigStorage())
+	_ = config.SetConfigPath("")
+}
+
+func (ms *memoryConfigStorage) GetSectionList() []string {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	return append([]string(nil), ms.order...)
+}
+
+func (ms *memoryConfigStorage) HasSection(section string) bool {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	_, exists := ms.sections[section]
+	return exists
+}
+
+func (ms *memoryConfigStorage) DeleteSection(section string) {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	if _, exists := ms.sections[section]; !exists {
+		return
+	}
+	delete(ms.sections, section)
+	for i, name := range ms.order {
+		if name == section {
+			ms.order = append(ms.order[:i], ms.order[i+1:]...)
+			break
+		}
+	}
+}
+
+func (ms *memoryConfigStorage) GetKeyList(section string) []string {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	keys := make([]string, 0, len(ms.sections[section]))
+	for key := range ms.sections[section] {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func (ms *memoryConfigStorage) GetValue(section string, key string) (string, bool) {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	value, found := ms.sections[section][key]
+	return value, found
+}
+
+func (ms *memoryConfigStorage) SetValue(section string, key string, value string) {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	if _, exists := ms.sections[section]; !exists {
+		ms.sections[section] = make(map[string]string)
+		ms.order = append(ms.order, section)
+	}
+	ms.sections[section][key] = value
+}
+
+func (ms *memoryConfigStorage) DeleteKey(section string, key string) bool {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	if _, found := ms.sections[section][key]; !found {
+		return false
+	}
+	delete(ms.sections[section], key)
+	return true
+}
+
+// Load has nothing to read; the remotes are created by handleRcloneConfig.
+func (ms *memoryConfigStorage) Load() error {
+	return nil
+}
+
+// Save has nothing to write, which is the point of this storage.
+func (ms *memoryConfigStorage) Save() error {
+	return nil
+}
+
+// Serialize is only used by rclone's config dump commands. The secrets stay in memory.
+func (ms *memoryConfigStorage) Serialize() (string, error) {
+	return "", nil
+}
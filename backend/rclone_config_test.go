@@ -0,0 +1,113 @@
+package backend
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// memoryCredentialStore is a CredentialStore for tests.
+type memoryCredentialStore struct {
+	secrets map[string]string
+	mu      sync.Mutex
+}
+
+func newMemoryCredentialStore() *memoryCredentialStore {
+	return &memoryCredentialStore{secrets: make(map[string]string)}
+}
+
+func (ms *memoryCredentialStore) Get(ref string) (string, error) {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	secret, exists := ms.secrets[ref]
+	if !exists {
+		return "", ErrCredentialNotFound
+	}
+	return secret, nil
+}
+
+func (ms *memoryCredentialStore) Set(ref string, secret string) error {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	ms.secrets[ref] = secret
+	return nil
+}
+
+func (ms *memoryCredentialStore) Delete(ref string) error {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	if _, exists := ms.secrets[ref]; !exists {
+		return ErrCredentialNotFound
+	}
+	delete(ms.secrets, ref)
+	return nil
+}
+
+var initRcloneOnce sync.Once
+
+// initTestRclone initializes the embedded rclone once for all tests.
+func initTestRclone() {
+	initRcloneOnce.Do(InitRclone)
+}
+
+func TestHandleRcloneConfigKeepsSecretsInMemory(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("USERPROFILE", home)
+	initTestRclone()
+
+	// A plaintext rclone.conf left behind by an earlier version
+	legacyPath, err := getLegacyRcloneConfigPath()
+	if err != nil {
+		t.Fatalf("getLegacyRcloneConfigPath failed: %v", err)
+	}
+	if err := os.WriteFile(legacyPath, []byte("[old]\ntype = b2\nkey = plaintext\n"), 0600); err != nil {
+		t.Fatalf("failed to write legacy config: %v", err)
+	}
+
+	store := newMemoryCredentialStore()
+	saveRemoteCredentials(store, "project:Alpha:remote", RemoteCredentials{Account: "account-id", Key: "super-secret-key"})
+	saveCryptCredentials(store, "project:Alpha:crypt", CryptCredentials{Password: "crypt-password"})
+	globalConfig := &GlobalConfig{Remotes: map[string]RemoteConfig{
+		"Alpha": {RemoteName: "alpha", BucketName: "alpha-bucket", Type: "b2", CredentialRef: "project:Alpha:remote", Encrypted: true, CryptRef: "project:Alpha:crypt"},
+		"Beta":  {RemoteName: "beta", BucketName: "beta-bucket", Type: "b2", CredentialRef: "project:Beta:remote"},
+	}}
+
+	if err := handleRcloneConfig(globalConfig, store); err != nil {
+		t.Fatalf("handleRcloneConfig failed: %v", err)
+	}
+	remotes, err := RcloneListRemotes()
+	if err != nil {
+		t.Fatalf("RcloneListRemotes failed: %v", err)
+	}
+	if want := []string{"alpha", "alpha-crypt", "beta"}; !reflect.DeepEqual(remotes, want) {
+		t.Errorf("remotes = %v, want %v", remotes, want)
+	}
+
+	// Removing a project removes its remote
+	delete(globalConfig.Remotes, "Beta")
+	if err := handleRcloneConfig(globalConfig, store); err != nil {
+		t.Fatalf("handleRcloneConfig failed: %v", err)
+	}
+	if remotes, _ := RcloneListRemotes(); !reflect.DeepEqual(remotes, []string{"alpha", "alpha-crypt"}) {
+		t.Errorf("remotes after removing a project = %v", remotes)
+	}
+
+	// Nothing under the home directory holds the secrets, and the legacy file is gone
+	if _, err := os.Stat(legacyPath); !os.IsNotExist(err) {
+		t.Errorf("legacy rclone.conf still exists: %v", err)
+	}
+	filepath.Walk(home, func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return err
+		}
+		data, readErr := os.ReadFile(path)
+		if readErr == nil && (strings.Contains(string(data), "super-secret-key") || strings.Contains(string(data), "crypt-password")) {
+			t.Errorf("%s contains a secret", path)
+		}
+		return nil
+	})
+}
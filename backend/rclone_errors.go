@@ -0,0 +1,149 @@
+package backend
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"os"
+	"strings"
+	"time"
+)
+
+// Kinds of errors reported in RcloneActionOutput and the task events, so the frontend can explain
+// what went wrong instead of showing the raw rclone output.
+const (
+	ErrorKindAuth        = "auth"         // The credentials were rejected or have expired
+	ErrorKindNotFound    = "not_found"    // The bucket, folder, or file doesn't exist
+	ErrorKindRateLimited = "rate_limited" // The storage provider asked us to slow down
+	ErrorKindNetwork     = "network"      // The remote couldn't be reached
+	ErrorKindPermission  = "permission"   // The credentials or the local user lack access
+	ErrorKindDiskFull    = "disk_full"    // The local volume is out of space
+	ErrorKindConfig      = "config"       // The project configuration is missing an entry the action needs
+	ErrorKindUnknown     = "unknown"
+)
+
+// Retries of rclone RPC calls that failed with a retryable error kind.
+const (
+	rcloneRPCMaxAttempts    = 4
+	rcloneRPCInitialBackoff = time.Second
+	rcloneRPCMaxBackoff     = 30 * time.Second
+)
+
+// RcloneError is a failed rclone RPC call, parsed from the error JSON rclone returns.
+type RcloneError struct {
+	Method  string
+	Status  int
+	Kind    string
+	Message string
+}
+
+func (e *RcloneError) Error() string {
+	return fmt.Sprintf("rclone %s failed (status %d): %s", e.Method, e.Status, e.Message)
+}
+
+// newRcloneError parses the output of a failed RPC call. rclone reports errors as
+// {"error": "...", "status": 500, ...}; anything else is kept as the message verbatim.
+func newRcloneError(method string, status int, output string) *RcloneError {
+	message := strings.TrimSpace(output)
+	var parsed struct {
+		Error string `json:"error"`
+	}
+	if err := json.Unmarshal([]byte(output), &parsed); err == nil && parsed.Error != "" {
+		message = parsed.Error
+	}
+	return &RcloneError{
+		Method:  method,
+		Status:  status,
+		Kind:    classifyErrorMessage(status, message),
+		Message: message,
+	}
+}
+
+// errorMarkers are lowercase fragments of error messages, from rclone, its backends (Backblaze B2
+// in particular), and the OS, that identify each kind. They are checked in order.
+var errorMarkers = []struct {
+	kind    string
+	markers []string
+}{
+	{ErrorKindDiskFull, []string{"no space left on device", "not enough space on the disk", "disk full"}},
+	{ErrorKindAuth, []string{"bad_auth_token", "expired_auth_token", "unauthorized", "(401", "invalid credentials", "invalidaccesskeyid", "signaturedoesnotmatch", "couldn't decrypt"}},
+	{ErrorKindRateLimited, []string{"too_many_requests", "too many requests", "rate limit", "(429", "service_unavailable", "(503", "slowdown"}},
+	{ErrorKindPermission, []string{"permission denied", "access denied", "access_denied", "access is denied", "accessdenied", "forbidden", "(403"}},
+	{ErrorKindNotFound, []string{"directory not found", "object not found", "file not found", "bucket not found", "no such bucket", "not_found", "no such file or directory", "cannot find the", "(404"}},
+	{ErrorKindNetwork, []string{"connection refused", "connection reset", "no such host", "i/o timeout", "network is unreachable", "tls handshake timeout", "unexpected eof", "broken pipe", "dial tcp", "context deadline exceeded", "temporary failure in name resolution", "server misbehaving"}},
+}
+
+// classifyErrorMessage returns the kind of error described by the message, using the RPC status
+// for the errors rclone maps to one.
+func classifyErrorMessage(status int, message string) string {
+	lower := strings.ToLower(message)
+	for _, entry := range errorMarkers {
+		for _, marker := range entry.markers {
+			if strings.Contains(lower, marker) {
+				return entry.kind
+			}
+		}
+	}
+	if status == 404 {
+		return ErrorKindNotFound
+	}
+	return ErrorKindUnknown
+}
+
+// errorKind returns the kind of the given error, or an empty string for no error. Errors that
+// were wrapped with %v lose their type, so their message is classified instead.
+func errorKind(err error) string {
+	if err == nil {
+		return ""
+	}
+	var diskErr *InsufficientDiskSpaceError
+	if errors.As(err, &diskErr) {
+		return ErrorKindDiskFull
+	}
+	var rcloneErr *RcloneError
+	if errors.As(err, &rcloneErr) {
+		return rcloneErr.Kind
+	}
+	if errors.Is(err, os.ErrPermission) {
+		return ErrorKindPermission
+	}
+	if errors.Is(err, os.ErrNotExist) {
+		return ErrorKindNotFound
+	}
+	return classifyErrorMessage(0, err.Error())
+}
+
+// rcloneRetryableMethods are the RC methods that are safe to call again after a failure: reads,
+// and syncs and copies, which only transfer what is still missing. Moves, deletes, and single-file
+// operations may already have partly happened, so they are never retried.
+var rcloneRetryableMethods = map[string]bool{
+	"operations/list": true,
+	"operations/stat": true,
+	"sync/sync":       true,
+	"sync/copy":       true,
+}
+
+// isRetryableRPC reports whether a failed call of the method may be retried. Async calls only
+// start a job, which may be running already, so they are never retried either.
+func isRetryableRPC(method string, params map[string]interface{}) bool {
+	if async, _ := params["_async"].(bool); async {
+		return false
+	}
+	return rcloneRetryableMethods[method]
+}
+
+// isRetryableErrorKind reports whether an error of the given kind may go away on its own.
+func isRetryableErrorKind(kind string) bool {
+	return kind == ErrorKindRateLimited || kind == ErrorKindNetwork
+}
+
+// rcloneRetryBackoff returns how long to wait before the given retry: exponential backoff with
+// jitter, so parallel transfers that failed together don't retry in lockstep.
+func rcloneRetryBackoff(attempt int) time.Duration {
+	backoff := rcloneRPCInitialBackoff << (attempt - 1)
+	if backoff > rcloneRPCMaxBackoff {
+		backoff = rcloneRPCMaxBackoff
+	}
+	return backoff/2 + rand.N(backoff/2)
+}
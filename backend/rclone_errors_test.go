@@ -0,0 +1,78 @@
+package backend
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestClassifyErrorMessage(t *testing.T) {
+	tests := []struct {
+		status  int
+		message string
+		want    string
+	}{
+		{500, "write /projects/demo/a.exr: no space left on device", ErrorKindDiskFull},
+		{401, "bad_auth_token (401 unauthorized)", ErrorKindAuth},
+		{500, "couldn't decrypt file: wrong password", ErrorKindAuth},
+		{500, "too_many_requests (429 Too Many Requests)", ErrorKindRateLimited},
+		{500, "service_unavailable (503 Service Unavailable)", ErrorKindRateLimited},
+		{500, "open C:\\projects\\demo\\a.exr: Access is denied.", ErrorKindPermission},
+		{500, "directory not found", ErrorKindNotFound},
+		{404, "no such key", ErrorKindNotFound},
+		{500, "dial tcp: lookup api.backblazeb2.com: no such host", ErrorKindNetwork},
+		{500, "read tcp 10.0.0.2:52144: i/o timeout", ErrorKindNetwork},
+		{500, "something else went wrong", ErrorKindUnknown},
+		// Checked in order, so a full disk wins over a 404 status
+		{404, "no space left on device", ErrorKindDiskFull},
+	}
+	for _, tt := range tests {
+		if got := classifyErrorMessage(tt.status, tt.message); got != tt.want {
+			t.Errorf("classifyErrorMessage(%d, %q) = %q, want %q", tt.status, tt.message, got, tt.want)
+		}
+	}
+}
+
+func TestIsRetryableRPC(t *testing.T) {
+	tests := []struct {
+		method string
+		params map[string]interface{}
+		want   bool
+	}{
+		{"operations/list", map[string]interface{}{"fs": "demo:bucket"}, true},
+		{"operations/stat", map[string]interface{}{"fs": "demo:bucket", "remote": "sync.json"}, true},
+		{"sync/sync", map[string]interface{}{"_async": false}, true},
+		{"sync/copy", map[string]interface{}{}, true},
+		{"sync/copy", map[string]interface{}{"_async": true}, false},
+		{"sync/move", map[string]interface{}{}, false},
+		{"operations/purge", map[string]interface{}{}, false},
+		{"operations/movefile", map[string]interface{}{}, false},
+		{"operations/copyfile", map[string]interface{}{}, false},
+		{"config/create", map[string]interface{}{}, false},
+	}
+	for _, tt := range tests {
+		if got := isRetryableRPC(tt.method, tt.params); got != tt.want {
+			t.Errorf("isRetryableRPC(%q, %v) = %v, want %v", tt.method, tt.params, got, tt.want)
+		}
+	}
+}
+
+func TestErrorKindNotFound(t *testing.T) {
+	initTestRclone()
+	_, listErr := rcloneListFiles(filepath.Join(t.TempDir(), "missing"))
+
+	tests := []struct {
+		name string
+		err  error
+	}{
+		{"listing a missing directory", listErr},
+		{"wrapped listing error", fmt.Errorf("failed to list remote folder: %v", listErr)},
+		{"missing local file", &os.PathError{Op: "open", Path: "a.exr", Err: os.ErrNotExist}},
+	}
+	for _, tt := range tests {
+		if got := errorKind(tt.err); got != ErrorKindNotFound {
+			t.Errorf("%s: errorKind(%v) = %q, want %q", tt.name, tt.err, got, ErrorKindNotFound)
+		}
+	}
+}
@@ -2,9 +2,11 @@ package backend
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"path/filepath"
 	"strings"
+	"sync"
 	"time"
 
 	_ "github.com/rclone/rclone/backend/all" // register all storage backends
@@ -21,9 +23,24 @@ type fileInfo struct {
 	IsDir   bool   `json:"IsDir"`
 }
 
+// rcloneTransferConfig is passed as _config on every call that transfers or lists files, so all of
+// them use the transfer profile of the selected project. nil keeps rclone's defaults.
+var (
+	rcloneTransferConfig   map[string]interface{}
+	rcloneTransferConfigMu sync.RWMutex
+)
+
+// setRcloneTransferConfig replaces the _config passed on every following transfer or listing call.
+func setRcloneTransferConfig(config map[string]interface{}) {
+	rcloneTransferConfigMu.Lock()
+	defer rcloneTransferConfigMu.Unlock()
+	rcloneTransferConfig = config
+}
+
 // InitRclone initializes the embedded rclone library. Call once at app startup.
 func InitRclone() {
 	librclone.Initialize()
+	useMemoryRcloneConfig()
 }
 
 // FinalizeRclone shuts down the embedded rclone library. Call on app shutdown.
@@ -32,17 +49,44 @@ func FinalizeRclone() {
 }
 
 // rcloneRPC is a low-level helper that calls an rclone RC method with JSON params.
-// Returns the output string and an error if the status is not 200.
+// Returns the output string and an *RcloneError if the status is not 200. Idempotent calls that
+// fail because of the network or rate limiting are retried with backoff. The selected project's transfer profile
+// is passed as _config to transfer and listing methods, unless the caller set one. The caller's
+// params are not changed.
 func rcloneRPC(method string, params map[string]interface{}) (string, error) {
+	rcloneTransferConfigMu.RLock()
+	if _, set := params["_config"]; !set && rcloneTransferConfig != nil && usesTransferConfig(method) {
+		withConfig := make(map[string]interface{}, len(params)+1)
+		for key, value := range params {
+			withConfig[key] = value
+		}
+		withConfig["_config"] = rcloneTransferConfig
+		params = withConfig
+	}
 	input, err := json.Marshal(params)
+	rcloneTransferConfigMu.RUnlock()
 	if err != nil {
 		return "", fmt.Errorf("failed to marshal RPC params: %v", err)
 	}
-	output, status := librclone.RPC(method, string(input))
-	if status != 200 {
-		return "", fmt.Errorf("rclone %s failed (status %d): %s", method, status, output)
+	for attempt := 1; ; attempt++ {
+		output, status := librclone.RPC(method, string(input))
+		if status == 200 {
+			return output, nil
+		}
+		rpcErr := newRcloneError(method, status, output)
+		if !isRetryableRPC(method, params) || !isRetryableErrorKind(rpcErr.Kind) || attempt >= rcloneRPCMaxAttempts {
+			return "", rpcErr
+		}
+		backoff := rcloneRetryBackoff(attempt)
+		fmt.Printf("[WARN] %v; retrying in %v (attempt %d of %d)\n", rpcErr, backoff.Round(time.Millisecond), attempt+1, rcloneRPCMaxAttempts)
+		time.Sleep(backoff)
 	}
-	return output, nil
+}
+
+// usesTransferConfig reports whether the transfer profile applies to the RC method: the sync and
+// file operations do, while config, core, and cache calls don't take it.
+func usesTransferConfig(method string) bool {
+	return strings.HasPrefix(method, "sync/") || strings.HasPrefix(method, "operations/")
 }
 
 // rcloneListFiles lists all files (recursively) at the given fs path.
@@ -78,27 +122,43 @@ type DiffEntry struct {
 
 // DiffResult is the structured diff output returned as JSON in CommandOutput.
 type DiffResult struct {
-	IsDiff     bool        `json:"isDiff"`     // marker so frontend can detect this is a diff
-	Additions  []DiffEntry `json:"additions"`
-	Updates    []DiffEntry `json:"updates"`
-	Deletions  []DiffEntry `json:"deletions"`
-	TotalSize  string      `json:"totalSize"`  // total size of source
-	ChangeSize string      `json:"changeSize"` // total size of additions + updates
+	IsDiff      bool        `json:"isDiff"` // marker so frontend can detect this is a diff
+	Additions   []DiffEntry `json:"additions"`
+	Updates     []DiffEntry `json:"updates"`
+	Deletions   []DiffEntry `json:"deletions"`
+	TotalSize   string      `json:"totalSize"`   // total size of source
+	ChangeSize  string      `json:"changeSize"`  // total size of additions + updates
+	ChangeBytes int64       `json:"changeBytes"` // total size of additions + updates, in bytes
 }
 
 // RcloneDiffFiles compares files between srcFs and dstFs and returns a structured diff.
 // Also returns a boolean indicating whether any changes were detected.
 func RcloneDiffFiles(srcFs, dstFs string) (string, bool, error) {
+	result, hasChanges, err := diffFiles(srcFs, dstFs)
+	if err != nil {
+		return "", false, err
+	}
+
+	jsonBytes, err := json.Marshal(result)
+	if err != nil {
+		return "", false, fmt.Errorf("failed to marshal diff result: %v", err)
+	}
+
+	return string(jsonBytes), hasChanges, nil
+}
+
+// diffFiles compares files between srcFs and dstFs by size and modification time.
+func diffFiles(srcFs, dstFs string) (DiffResult, bool, error) {
 	startTime := time.Now()
 	_ = startTime // reserved for future timing stats
 
 	srcFiles, err := rcloneListFiles(srcFs)
 	if err != nil {
-		return "", false, fmt.Errorf("failed to list source: %v", err)
+		return DiffResult{}, false, fmt.Errorf("failed to list source: %v", err)
 	}
 	dstFiles, err := rcloneListFiles(dstFs)
 	if err != nil {
-		return "", false, fmt.Errorf("failed to list destination: %v", err)
+		return DiffResult{}, false, fmt.Errorf("failed to list destination: %v", err)
 	}
 
 	// Build maps by path (excluding directories)
@@ -175,20 +235,16 @@ func RcloneDiffFiles(srcFs, dstFs string) (string, bool, error) {
 	hasChanges := len(additions) > 0 || len(updates) > 0 || len(deletions) > 0
 
 	result := DiffResult{
-		IsDiff:     true,
-		Additions:  additions,
-		Updates:    updates,
-		Deletions:  deletions,
-		TotalSize:  formatSize(totalSrcSize),
-		ChangeSize: formatSize(changeSize),
+		IsDiff:      true,
+		Additions:   additions,
+		Updates:     updates,
+		Deletions:   deletions,
+		TotalSize:   formatSize(totalSrcSize),
+		ChangeSize:  formatSize(changeSize),
+		ChangeBytes: changeSize,
 	}
 
-	jsonBytes, err := json.Marshal(result)
-	if err != nil {
-		return "", false, fmt.Errorf("failed to marshal diff result: %v", err)
-	}
-
-	return string(jsonBytes), hasChanges, nil
+	return result, hasChanges, nil
 }
 
 // formatSize returns a human-readable file size.
@@ -261,6 +317,29 @@ func RcloneCopy(srcFs, dstFs string, dryRun bool) (string, error) {
 	return "Copy completed successfully.", nil
 }
 
+// RcloneMove moves the contents of srcFs to dstFs. On a remote that supports it the move is done
+// server-side, and source directories left empty are removed.
+func RcloneMove(srcFs, dstFs string) error {
+	params := map[string]interface{}{
+		"srcFs":              srcFs,
+		"dstFs":              dstFs,
+		"deleteEmptySrcDirs": true,
+		"_async":             false,
+	}
+	_, err := rcloneRPC("sync/move", params)
+	return err
+}
+
+// RclonePurge deletes the given path on a remote together with all of its contents.
+func RclonePurge(fsPath string, remote string) error {
+	params := map[string]interface{}{
+		"fs":     fsPath,
+		"remote": remote,
+	}
+	_, err := rcloneRPC("operations/purge", params)
+	return err
+}
+
 // RcloneHasChanges compares srcFs and dstFs and returns whether any files differ.
 func RcloneHasChanges(srcFs, dstFs string) (bool, error) {
 	_, hasChanges, err := RcloneDiffFiles(srcFs, dstFs)
@@ -279,6 +358,16 @@ func RcloneCopyFile(srcFs, srcRemote, dstFs, dstRemote string) error {
 	return err
 }
 
+// RcloneSetBandwidthLimit sets rclone's bandwidth limit, an "upload:download" pair of rates. The
+// limiter is shared by every transfer, including the ones already running.
+func RcloneSetBandwidthLimit(rate string) error {
+	params := map[string]interface{}{
+		"rate": rate,
+	}
+	_, err := rcloneRPC("core/bwlimit", params)
+	return err
+}
+
 // RcloneListJSON lists files at the given fs path, returning the raw JSON output.
 func RcloneListJSON(fsPath string, remote string) (string, error) {
 	params := map[string]interface{}{
@@ -303,38 +392,131 @@ func RcloneGetConfigPath() (string, error) {
 	return filepath.Clean(result.Config), nil
 }
 
-// RcloneGetRemoteFileModTime gets the modification time of a remote file.
-func RcloneGetRemoteFileModTime(remotePath string) (time.Time, error) {
-	// Parse "remote:bucket/path/to/file.json" into fs="remote:bucket/path/to" and remote="file.json"
-	lastSlash := strings.LastIndex(remotePath, "/")
-	if lastSlash == -1 {
-		return time.Time{}, fmt.Errorf("invalid remote path format: %s", remotePath)
+// RcloneCreateRemote creates the named remote in rclone's config, replacing any remote with the
+// same name. Password parameters are given in the clear and obscured by rclone.
+func RcloneCreateRemote(name string, remoteType string, parameters map[string]string) error {
+	params := map[string]interface{}{
+		"name":       name,
+		"type":       remoteType,
+		"parameters": parameters,
+		"opt": map[string]interface{}{
+			"obscure":        true,
+			"nonInteractive": true,
+			"noOutput":       true,
+		},
 	}
-	fsPath := remotePath[:lastSlash]
-	fileName := remotePath[lastSlash+1:]
+	_, err := rcloneRPC("config/create", params)
+	return err
+}
+
+// RcloneDeleteRemote deletes the named remote from rclone's config.
+func RcloneDeleteRemote(name string) error {
+	_, err := rcloneRPC("config/delete", map[string]interface{}{"name": name})
+	return err
+}
 
-	output, err := RcloneListJSON(fsPath, fileName)
+// RcloneListRemotes returns the names of the remotes in rclone's config.
+func RcloneListRemotes() ([]string, error) {
+	output, err := rcloneRPC("config/listremotes", map[string]interface{}{})
 	if err != nil {
-		return time.Time{}, fmt.Errorf("rclone list failed: %v", err)
+		return nil, err
 	}
-
 	var result struct {
-		List []struct {
-			ModTime string `json:"ModTime"`
-		} `json:"list"`
+		Remotes []string `json:"remotes"`
 	}
 	if err := json.Unmarshal([]byte(output), &result); err != nil {
-		return time.Time{}, fmt.Errorf("failed to parse list output: %v", err)
+		return nil, fmt.Errorf("failed to parse remote list: %v", err)
 	}
+	return result.Remotes, nil
+}
 
-	if len(result.List) == 0 {
-		return time.Time{}, fmt.Errorf("file not found on remote")
+// RcloneClearFsCache drops the remotes rclone has cached, so the next call to each remote picks
+// up its current config.
+func RcloneClearFsCache() error {
+	_, err := rcloneRPC("fscache/clear", map[string]interface{}{})
+	return err
+}
+
+// errRemoteFileNotFound is returned by RcloneGetRemoteFileModTime for a file that doesn't exist.
+var errRemoteFileNotFound = errors.New("file not found on remote")
+
+// RcloneGetRemoteFileModTime gets the modification time of the file at remote within fsPath. The
+// fs and the path within it are passed separately, as the root of an encrypted project is a bare
+// "name-crypt:" with no path to split off.
+func RcloneGetRemoteFileModTime(fsPath string, remote string) (time.Time, error) {
+	state, err := RcloneStatRemoteFile(fsPath, remote)
+	if err != nil {
+		return time.Time{}, err
 	}
+	return state.modTime()
+}
+
+// RemoteFileState identifies one version of a file: its modification time, its size, and the
+// hashes its remote supports. Encrypted remotes have no hashes.
+type RemoteFileState struct {
+	ModTime string            `json:"mod_time"` // RFC3339 with the remote's precision
+	Size    int64             `json:"size"`
+	Hashes  map[string]string `json:"hashes"` // Keyed by hash type, such as "sha1"
+}
 
-	modTime, err := time.Parse(time.RFC3339Nano, result.List[0].ModTime)
+func (rfs RemoteFileState) modTime() (time.Time, error) {
+	modTime, err := time.Parse(time.RFC3339Nano, rfs.ModTime)
 	if err != nil {
 		return time.Time{}, fmt.Errorf("failed to parse remote mod time: %v", err)
 	}
-
 	return modTime, nil
 }
+
+// sameVersion reports whether both states describe the same file content. A hash both states have
+// decides; without one, the size and the mod time to the second must match.
+func (rfs RemoteFileState) sameVersion(other RemoteFileState) bool {
+	for hashType, hash := range rfs.Hashes {
+		if otherHash := other.Hashes[hashType]; hash != "" && otherHash != "" {
+			return hash == otherHash
+		}
+	}
+	modTime, err := rfs.modTime()
+	if err != nil {
+		return false
+	}
+	otherModTime, err := other.modTime()
+	if err != nil {
+		return false
+	}
+	return rfs.Size == other.Size && modTime.Truncate(time.Second).Equal(otherModTime.Truncate(time.Second))
+}
+
+// RcloneStatRemoteFile gets the state of the file at remote within fsPath, or
+// errRemoteFileNotFound if there is no such file.
+func RcloneStatRemoteFile(fsPath string, remote string) (RemoteFileState, error) {
+	params := map[string]interface{}{
+		"fs":     fsPath,
+		"remote": remote,
+		"opt": map[string]interface{}{
+			"filesOnly":  true,
+			"noMimeType": true,
+			"showHash":   true,
+		},
+	}
+	output, err := rcloneRPC("operations/stat", params)
+	if err != nil {
+		return RemoteFileState{}, fmt.Errorf("rclone stat failed: %v", err)
+	}
+
+	var result struct {
+		Item *struct {
+			ModTime string            `json:"ModTime"`
+			Size    int64             `json:"Size"`
+			Hashes  map[string]string `json:"Hashes"`
+		} `json:"item"`
+	}
+	if err := json.Unmarshal([]byte(output), &result); err != nil {
+		return RemoteFileState{}, fmt.Errorf("failed to parse stat output: %v", err)
+	}
+
+	if result.Item == nil {
+		return RemoteFileState{}, errRemoteFileNotFound
+	}
+
+	return RemoteFileState{ModTime: result.Item.ModTime, Size: result.Item.Size, Hashes: result.Item.Hashes}, nil
+}
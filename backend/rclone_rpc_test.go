@@ -0,0 +1,99 @@
+package backend
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestRcloneGetRemoteFileModTimeEncryptedRoot(t *testing.T) {
+	initTestRclone()
+
+	// A crypt remote over a local directory has a root of "name-crypt:", with no path in it
+	bucketDir := t.TempDir()
+	if err := RcloneCreateRemote("statlocal", "local", map[string]string{}); err != nil {
+		t.Fatalf("failed to create local remote: %v", err)
+	}
+	if err := RcloneCreateRemote("statlocal-crypt", "crypt", map[string]string{"remote": "statlocal:" + bucketDir, "password": "crypt-password"}); err != nil {
+		t.Fatalf("failed to create crypt remote: %v", err)
+	}
+	remote := RemoteConfig{RemoteName: "statlocal", BucketName: bucketDir, Encrypted: true}
+
+	localDir := t.TempDir()
+	modTime := time.Date(2026, 3, 4, 5, 6, 7, 0, time.UTC)
+	if err := os.WriteFile(filepath.Join(localDir, "sync.json"), []byte("{}"), 0644); err != nil {
+		t.Fatalf("failed to write sync.json: %v", err)
+	}
+	if err := os.Chtimes(filepath.Join(localDir, "sync.json"), modTime, modTime); err != nil {
+		t.Fatalf("failed to set mod time: %v", err)
+	}
+	if err := RcloneCopyFile(localDir, "sync.json", remote.remoteRoot(), "sync.json"); err != nil {
+		t.Fatalf("failed to upload sync.json: %v", err)
+	}
+
+	got, err := RcloneGetRemoteFileModTime(remote.remoteRoot(), "sync.json")
+	if err != nil {
+		t.Fatalf("RcloneGetRemoteFileModTime failed: %v", err)
+	}
+	if !got.Equal(modTime) {
+		t.Errorf("mod time = %v, want %v", got, modTime)
+	}
+
+	if _, err := RcloneGetRemoteFileModTime(remote.remoteRoot(), "missing.json"); err == nil {
+		t.Errorf("RcloneGetRemoteFileModTime of a missing file succeeded")
+	}
+}
+
+func TestRcloneRPCKeepsCallerParams(t *testing.T) {
+	initTestRclone()
+	setRcloneTransferConfig((&TransferProfile{Transfers: 8}).rcloneConfig())
+	defer setRcloneTransferConfig(nil)
+
+	tests := []struct {
+		method string
+		params map[string]interface{}
+	}{
+		{"operations/list", map[string]interface{}{"fs": t.TempDir(), "remote": ""}},
+		{"config/listremotes", map[string]interface{}{}},
+		{"core/bwlimit", map[string]interface{}{"rate": "off"}},
+	}
+	for _, tt := range tests {
+		before := len(tt.params)
+		if _, err := rcloneRPC(tt.method, tt.params); err != nil {
+			t.Errorf("%s failed: %v", tt.method, err)
+		}
+		if _, set := tt.params["_config"]; set || len(tt.params) != before {
+			t.Errorf("%s changed the caller's params: %v", tt.method, tt.params)
+		}
+	}
+
+	if !usesTransferConfig("sync/copy") || !usesTransferConfig("operations/list") {
+		t.Errorf("transfer and listing methods don't use the transfer profile")
+	}
+	for _, method := range []string{"config/setpath", "core/obscure", "core/bwlimit", "fscache/clear"} {
+		if usesTransferConfig(method) {
+			t.Errorf("%s uses the transfer profile", method)
+		}
+	}
+}
+
+func TestRemoteFileStateSameVersion(t *testing.T) {
+	base := RemoteFileState{ModTime: "2026-10-19T10:00:00.123Z", Size: 100, Hashes: map[string]string{"sha1": "aaa"}}
+	tests := []struct {
+		name  string
+		other RemoteFileState
+		want  bool
+	}{
+		{"same hash, other mod time", RemoteFileState{ModTime: "2026-10-19T11:00:00Z", Size: 100, Hashes: map[string]string{"sha1": "aaa"}}, true},
+		{"other hash, same mod time and size", RemoteFileState{ModTime: "2026-10-19T10:00:00.123Z", Size: 100, Hashes: map[string]string{"sha1": "bbb"}}, false},
+		{"no common hash, mod time to the second", RemoteFileState{ModTime: "2026-10-19T10:00:00Z", Size: 100, Hashes: map[string]string{"md5": "ccc"}}, true},
+		{"no hash, earlier mod time", RemoteFileState{ModTime: "2026-10-19T09:00:00Z", Size: 100}, false},
+		{"no hash, other size", RemoteFileState{ModTime: "2026-10-19T10:00:00Z", Size: 101}, false},
+	}
+	for _, tt := range tests {
+		if got := base.sameVersion(tt.other); got != tt.want {
+			t.Errorf("%s: sameVersion = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
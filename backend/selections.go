@@ -0,0 +1,237 @@
+package backend
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// SelectionConfig is a saved, named set of folders, such as "Episode 3 lighting" or "All audio".
+// A folder belongs to the selection if it matches any tag query, is in any of the groups or their
+// descendant groups, or is listed explicitly.
+type SelectionConfig struct {
+	Name       string   `json:"name"`        // Display name
+	TagQueries []string `json:"tag_queries"` // Each query is a set of tags joined with "+"; a folder must have all of them
+	Groups     []string `json:"groups"`
+	Folders    []string `json:"folders"`
+}
+
+// clone returns a copy of the selection that shares no slices with the original.
+func (sc SelectionConfig) clone() SelectionConfig {
+	sc.TagQueries = cloneStrings(sc.TagQueries)
+	sc.Groups = cloneStrings(sc.Groups)
+	sc.Folders = cloneStrings(sc.Folders)
+	return sc
+}
+
+// normalizeTags trims and lowercases tags, dropping empty and duplicate ones.
+func normalizeTags(tags []string) []string {
+	seen := make(map[string]bool, len(tags))
+	normalized := []string{}
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag == "" || seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		normalized = append(normalized, tag)
+	}
+	sort.Strings(normalized)
+	return normalized
+}
+
+// parseTagQuery splits a tag query such as "episode-3+lighting" into its normalized tags.
+func parseTagQuery(query string) []string {
+	return normalizeTags(strings.Split(query, "+"))
+}
+
+// folderMatchesTags reports whether the folder has every one of the given tags.
+func folderMatchesTags(folderConfig FolderConfig, tags []string) bool {
+	if len(tags) == 0 {
+		return false
+	}
+	folderTags := make(map[string]bool, len(folderConfig.Tags))
+	for _, tag := range normalizeTags(folderConfig.Tags) {
+		folderTags[tag] = true
+	}
+	for _, tag := range tags {
+		if !folderTags[tag] {
+			return false
+		}
+	}
+	return true
+}
+
+// groupWithDescendants returns the given group and every group nested below it.
+func groupWithDescendants(projectConfig *ProjectConfig, groupKey string) map[string]bool {
+	groups := map[string]bool{groupKey: true}
+	for added := true; added; {
+		added = false
+		for childKey, childGroup := range projectConfig.Groups {
+			if groups[childGroup.ParentGroup] && !groups[childKey] {
+				groups[childKey] = true
+				added = true
+			}
+		}
+	}
+	return groups
+}
+
+// resolveSelection returns the sorted keys of every folder in the named selection. Folder and
+// group references that no longer exist are skipped.
+func resolveSelection(projectConfig *ProjectConfig, selectionKey string) ([]string, error) {
+	selection, exists := projectConfig.Selections[selectionKey]
+	if !exists {
+		return nil, fmt.Errorf("selection '%s' does not exist", selectionKey)
+	}
+
+	selectedGroups := make(map[string]bool)
+	for _, groupKey := range selection.Groups {
+		for key := range groupWithDescendants(projectConfig, groupKey) {
+			selectedGroups[key] = true
+		}
+	}
+	tagQueries := make([][]string, 0, len(selection.TagQueries))
+	for _, query := range selection.TagQueries {
+		tagQueries = append(tagQueries, parseTagQuery(query))
+	}
+
+	selected := make(map[string]bool)
+	for _, folderKey := range selection.Folders {
+		if _, exists := projectConfig.Folders[folderKey]; exists {
+			selected[folderKey] = true
+		}
+	}
+	for folderKey, folderConfig := range projectConfig.Folders {
+		if selectedGroups[folderConfig.Group] {
+			selected[folderKey] = true
+			continue
+		}
+		for _, tags := range tagQueries {
+			if folderMatchesTags(folderConfig, tags) {
+				selected[folderKey] = true
+				break
+			}
+		}
+	}
+
+	folderKeys := make([]string, 0, len(selected))
+	for folderKey := range selected {
+		folderKeys = append(folderKeys, folderKey)
+	}
+	sort.Strings(folderKeys)
+	return folderKeys, nil
+}
+
+// resolveTargetFolders combines an explicit folder list with the folders of a named selection.
+// An empty selection name just returns the folder list.
+func resolveTargetFolders(projectConfig *ProjectConfig, targetFolders []string, selectionName string) ([]string, error) {
+	if selectionName == "" {
+		return targetFolders, nil
+	}
+	selectionFolders, err := resolveSelection(projectConfig, selectionName)
+	if err != nil {
+		return nil, err
+	}
+	seen := make(map[string]bool, len(targetFolders)+len(selectionFolders))
+	combined := make([]string, 0, len(targetFolders)+len(selectionFolders))
+	for _, folderKey := range append(append([]string{}, targetFolders...), selectionFolders...) {
+		if !seen[folderKey] {
+			seen[folderKey] = true
+			combined = append(combined, folderKey)
+		}
+	}
+	return combined, nil
+}
+
+// replaceSelectionReferences renames a folder or group key in every selection, or removes it when
+// newKey is empty. kind is either "folder" or "group".
+func replaceSelectionReferences(projectConfig *ProjectConfig, kind string, oldKey string, newKey string) {
+	for selectionKey, selection := range projectConfig.Selections {
+		var refs *[]string
+		if kind == "group" {
+			refs = &selection.Groups
+		} else {
+			refs = &selection.Folders
+		}
+		updated := make([]string, 0, len(*refs))
+		for _, ref := range *refs {
+			if ref != oldKey {
+				updated = append(updated, ref)
+			} else if newKey != "" {
+				updated = append(updated, newKey)
+			}
+		}
+		*refs = updated
+		projectConfig.Selections[selectionKey] = selection
+	}
+}
+
+// SaveSelection creates or replaces the named folder selection.
+func (fs *FolderService) SaveSelection(selectionKey string, selection SelectionConfig) (ProjectConfig, error) {
+	return fs.updateProjectConfig(func(projectConfig *ProjectConfig, projectRoot string) error {
+		return applySaveSelection(projectConfig, selectionKey, selection)
+	})
+}
+
+// applySaveSelection creates or replaces a selection in the project config.
+func applySaveSelection(projectConfig *ProjectConfig, selectionKey string, selection SelectionConfig) error {
+	selectionKey = strings.TrimSpace(selectionKey)
+	if selectionKey == "" {
+		return fmt.Errorf("a selection key must be specified")
+	}
+	if strings.TrimSpace(selection.Name) == "" {
+		selection.Name = selectionKey
+	}
+	for _, groupKey := range selection.Groups {
+		if _, exists := projectConfig.Groups[groupKey]; !exists {
+			return fmt.Errorf("group '%s' does not exist", groupKey)
+		}
+	}
+	for _, folderKey := range selection.Folders {
+		if _, exists := projectConfig.Folders[folderKey]; !exists {
+			return fmt.Errorf("folder '%s' does not exist in the project configuration", folderKey)
+		}
+	}
+	queries := []string{}
+	for _, query := range selection.TagQueries {
+		if tags := parseTagQuery(query); len(tags) > 0 {
+			queries = append(queries, strings.Join(tags, "+"))
+		}
+	}
+	selection.TagQueries = queries
+	if len(selection.TagQueries) == 0 && len(selection.Groups) == 0 && len(selection.Folders) == 0 {
+		return fmt.Errorf("selection '%s' must include at least one tag query, group, or folder", selectionKey)
+	}
+
+	if projectConfig.Selections == nil {
+		projectConfig.Selections = make(map[string]SelectionConfig)
+	}
+	projectConfig.Selections[selectionKey] = selection
+	return nil
+}
+
+// DeleteSelection removes the named folder selection. The folders themselves are not affected.
+func (fs *FolderService) DeleteSelection(selectionKey string) (ProjectConfig, error) {
+	return fs.updateProjectConfig(func(projectConfig *ProjectConfig, projectRoot string) error {
+		return applyDeleteSelection(projectConfig, selectionKey)
+	})
+}
+
+// applyDeleteSelection removes a selection from the project config.
+func applyDeleteSelection(projectConfig *ProjectConfig, selectionKey string) error {
+	if _, exists := projectConfig.Selections[selectionKey]; !exists {
+		return fmt.Errorf("selection '%s' does not exist", selectionKey)
+	}
+	delete(projectConfig.Selections, selectionKey)
+	return nil
+}
+
+// ResolveSelection returns the keys of the folders currently in the named selection.
+func (fs *FolderService) ResolveSelection(selectionKey string) ([]string, error) {
+	projectConfig, err := fs.getProjectConfig()
+	if err != nil {
+		return nil, err
+	}
+	return resolveSelection(projectConfig, selectionKey)
+}
@@ -1,8 +1,10 @@
 package backend
 
 import (
+	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
 	"sync"
 )
 
@@ -15,9 +17,24 @@ func NewSyncService(configManager *ConfigManager) *SyncService {
 }
 
 type RcloneActionOutput struct {
-	TargetFolder  string `json:"target_folder"`
-	CommandOutput string `json:"command_output"`
-	CommandError  string `json:"command_error"`
+	TargetFolder   string `json:"target_folder"`
+	CommandOutput  string `json:"command_output"`
+	CommandError   string `json:"command_error"`
+	ErrorKind      string `json:"error_kind"`      // One of the ErrorKind constants; empty when there is no error
+	RequiredBytes  int64  `json:"required_bytes"`  // Bytes needed, when refused for lack of disk space
+	AvailableBytes int64  `json:"available_bytes"` // Bytes free on the volume, when refused for lack of disk space
+}
+
+// actionErrorOutput returns the output of an action that failed with the given error. A disk
+// space error also reports how many bytes were required and available.
+func actionErrorOutput(targetFolder string, err error) RcloneActionOutput {
+	output := RcloneActionOutput{TargetFolder: targetFolder, CommandOutput: "", CommandError: err.Error(), ErrorKind: errorKind(err)}
+	var diskErr *InsufficientDiskSpaceError
+	if errors.As(err, &diskErr) {
+		output.RequiredBytes = diskErr.RequiredBytes
+		output.AvailableBytes = diskErr.AvailableBytes
+	}
+	return output
 }
 
 // executeSingleFolder runs a single rclone action for one folder and returns the result.
@@ -30,28 +47,33 @@ func (ss *SyncService) executeSingleFolder(targetFolder string, action RcloneAct
 			TargetFolder:  targetFolder,
 			CommandOutput: "",
 			CommandError:  fmt.Errorf("target folder configuration not found: %s", targetFolder).Error(),
+			ErrorKind:     ErrorKindConfig,
 		}
 	}
 
 	// Get the remote config from the global config
 	remoteConfig := ss.configManager.GetGlobalConfig().Remotes[ss.configManager.GetGlobalConfig().SelectedProject]
-	fullLocalPath := fmt.Sprintf("%s/%s", remoteConfig.LocalPath, folderConfig.LocalPath)
-	fullRemotePath := fmt.Sprintf("%s:%s/%s", remoteConfig.RemoteName, remoteConfig.BucketName, folderConfig.RemotePath)
+	fullLocalPath, fullRemotePath, err := resolveFolderPaths(&remoteConfig, targetFolder, folderConfig)
+	if err != nil {
+		return actionErrorOutput(targetFolder, err)
+	}
 
 	// Check if the local directory exists
-	_, err := os.Stat(fullLocalPath)
+	_, err = os.Stat(fullLocalPath)
 	if !IsFolderOptional(action) {
 		if os.IsNotExist(err) {
 			return RcloneActionOutput{
 				TargetFolder:  targetFolder,
 				CommandOutput: "",
 				CommandError:  fmt.Errorf("local path does not exist: %s", fullLocalPath).Error(),
+				ErrorKind:     ErrorKindNotFound,
 			}
 		} else if err != nil {
 			return RcloneActionOutput{
 				TargetFolder:  targetFolder,
 				CommandOutput: "",
 				CommandError:  fmt.Errorf("error accessing local path %s: %v", fullLocalPath, err).Error(),
+				ErrorKind:     errorKind(err),
 			}
 		}
 	}
@@ -68,19 +90,41 @@ func (ss *SyncService) executeSingleFolder(targetFolder string, action RcloneAct
 	case COPY_PULL:
 		output, rpcErr = RcloneCopy(fullRemotePath, fullLocalPath, dry)
 	default:
-		return RcloneActionOutput{TargetFolder: targetFolder, CommandOutput: "", CommandError: fmt.Sprintf("unsupported action: %s", action)}
+		return RcloneActionOutput{TargetFolder: targetFolder, CommandOutput: "", CommandError: fmt.Sprintf("unsupported action: %s", action), ErrorKind: ErrorKindUnknown}
 	}
 
 	if rpcErr != nil {
-		return RcloneActionOutput{TargetFolder: targetFolder, CommandOutput: "", CommandError: rpcErr.Error()}
+		return actionErrorOutput(targetFolder, rpcErr)
+	}
+	if !dry {
+		ss.recordActionResult(targetFolder, action)
 	}
 
 	return RcloneActionOutput{TargetFolder: targetFolder, CommandOutput: output, CommandError: ""}
 }
 
+// preflightAction checks a selection before any folder is touched. Overlapping folders are refused,
+// and real pulls must fit on the local volume.
+func (ss *SyncService) preflightAction(targetFolders []string, action RcloneAction, dry bool) error {
+	if err := checkSelectionOverlap(ss.configManager.GetProjectConfig(), targetFolders); err != nil {
+		return err
+	}
+	if isPullAction(action) && !dry {
+		return ss.preflightPullDiskSpace(targetFolders, nil)
+	}
+	return nil
+}
+
 // Error handling is done per request, and gracefully returned to the user for evaluation in the frontend.
 func (ss *SyncService) ExecuteRcloneAction(targetFolders []string, action RcloneAction, dry bool) []RcloneActionOutput {
 	var outputs []RcloneActionOutput
+	if err := ss.preflightAction(targetFolders, action, dry); err != nil {
+		for _, targetFolder := range targetFolders {
+			outputs = append(outputs, actionErrorOutput(targetFolder, err))
+		}
+		return outputs
+	}
+
 	var wg sync.WaitGroup
 	resultChan := make(chan RcloneActionOutput, len(targetFolders))
 
@@ -104,9 +148,25 @@ func (ss *SyncService) ExecuteRcloneAction(targetFolders []string, action Rclone
 // ExecuteRcloneActionAsync runs the rclone action for each folder in parallel,
 // emitting a "task-folder-complete" event as each folder finishes.
 // When all folders are done, emits a "task-complete" event.
+// The folders of the named selection, if one is given, are added to the target folders.
 // Returns immediately; the taskID correlates events to the original request.
-func (ss *SyncService) ExecuteRcloneActionAsync(taskID string, targetFolders []string, action RcloneAction, dry bool) error {
+func (ss *SyncService) ExecuteRcloneActionAsync(taskID string, targetFolders []string, selectionName string, action RcloneAction, dry bool) error {
 	go func() {
+		targetFolders, err := resolveTargetFolders(ss.configManager.GetProjectConfig(), targetFolders, selectionName)
+		if err != nil {
+			emitEvent(EventTaskFolderComplete, newTaskFolderCompletePayload(taskID, actionErrorOutput(selectionName, err)))
+			emitEvent(EventTaskComplete, TaskCompletePayload{TaskID: taskID})
+			return
+		}
+
+		if err := ss.preflightAction(targetFolders, action, dry); err != nil {
+			for _, tf := range targetFolders {
+				emitEvent(EventTaskFolderComplete, newTaskFolderCompletePayload(taskID, actionErrorOutput(tf, err)))
+			}
+			emitEvent(EventTaskComplete, TaskCompletePayload{TaskID: taskID})
+			return
+		}
+
 		var wg sync.WaitGroup
 
 		for _, tf := range targetFolders {
@@ -114,12 +174,7 @@ func (ss *SyncService) ExecuteRcloneActionAsync(taskID string, targetFolders []s
 			go func(tf string) {
 				defer wg.Done()
 				result := ss.executeSingleFolder(tf, action, dry)
-				emitEvent(EventTaskFolderComplete, TaskFolderCompletePayload{
-					TaskID:        taskID,
-					TargetFolder:  result.TargetFolder,
-					CommandOutput: result.CommandOutput,
-					CommandError:  result.CommandError,
-				})
+				emitEvent(EventTaskFolderComplete, newTaskFolderCompletePayload(taskID, result))
 			}(tf)
 		}
 
@@ -136,7 +191,7 @@ func (ss *SyncService) ExecuteFullBackup(dry bool) []RcloneActionOutput {
 	remoteConfig := ss.configManager.GetGlobalConfig().Remotes[selectedProject]
 	selectedProject = selectedProject + " - Backup"
 	fullLocalPath := remoteConfig.FullBackupPath
-	fullRemotePath := fmt.Sprintf("%s:%s", remoteConfig.RemoteName, remoteConfig.BucketName)
+	fullRemotePath := remoteConfig.remoteRoot()
 
 	_, err := os.Stat(fullLocalPath)
 	if os.IsNotExist(err) {
@@ -144,17 +199,21 @@ func (ss *SyncService) ExecuteFullBackup(dry bool) []RcloneActionOutput {
 			TargetFolder:  selectedProject,
 			CommandOutput: "",
 			CommandError:  fmt.Errorf("local path does not exist: %s", fullLocalPath).Error(),
+			ErrorKind:     ErrorKindNotFound,
 		})
 	} else if err != nil {
 		outputs = append(outputs, RcloneActionOutput{
 			TargetFolder:  selectedProject,
 			CommandOutput: "",
 			CommandError:  fmt.Errorf("error accessing local path %s: %v", fullLocalPath, err).Error(),
+			ErrorKind:     errorKind(err),
 		})
+	} else if spaceErr := ss.preflightBackup(remoteConfig, dry); spaceErr != nil {
+		outputs = append(outputs, actionErrorOutput(selectedProject, spaceErr))
 	} else {
 		output, rpcErr := RcloneSync(fullRemotePath, fullLocalPath, dry)
 		if rpcErr != nil {
-			outputs = append(outputs, RcloneActionOutput{TargetFolder: selectedProject, CommandOutput: "", CommandError: rpcErr.Error()})
+			outputs = append(outputs, actionErrorOutput(selectedProject, rpcErr))
 		} else {
 			outputs = append(outputs, RcloneActionOutput{TargetFolder: selectedProject, CommandOutput: output, CommandError: ""})
 		}
@@ -162,6 +221,14 @@ func (ss *SyncService) ExecuteFullBackup(dry bool) []RcloneActionOutput {
 	return outputs
 }
 
+// preflightBackup checks that a real backup fits on the backup volume. Previews download nothing.
+func (ss *SyncService) preflightBackup(remoteConfig RemoteConfig, dry bool) error {
+	if dry {
+		return nil
+	}
+	return preflightBackupDiskSpace(remoteConfig)
+}
+
 // ExecuteFullBackupAsync runs the full backup in a background goroutine,
 // emitting events as the operation completes.
 func (ss *SyncService) ExecuteFullBackupAsync(taskID string, dry bool) error {
@@ -170,29 +237,155 @@ func (ss *SyncService) ExecuteFullBackupAsync(taskID string, dry bool) error {
 		remoteConfig := ss.configManager.GetGlobalConfig().Remotes[selectedProject]
 		label := selectedProject + " - Backup"
 		fullLocalPath := remoteConfig.FullBackupPath
-		fullRemotePath := fmt.Sprintf("%s:%s", remoteConfig.RemoteName, remoteConfig.BucketName)
+		fullRemotePath := remoteConfig.remoteRoot()
 
 		var result RcloneActionOutput
 		_, err := os.Stat(fullLocalPath)
 		if os.IsNotExist(err) {
-			result = RcloneActionOutput{TargetFolder: label, CommandOutput: "", CommandError: fmt.Sprintf("local path does not exist: %s", fullLocalPath)}
+			result = RcloneActionOutput{TargetFolder: label, CommandOutput: "", CommandError: fmt.Sprintf("local path does not exist: %s", fullLocalPath), ErrorKind: ErrorKindNotFound}
 		} else if err != nil {
-			result = RcloneActionOutput{TargetFolder: label, CommandOutput: "", CommandError: fmt.Sprintf("error accessing local path %s: %v", fullLocalPath, err)}
+			result = RcloneActionOutput{TargetFolder: label, CommandOutput: "", CommandError: fmt.Sprintf("error accessing local path %s: %v", fullLocalPath, err), ErrorKind: errorKind(err)}
+		} else if spaceErr := ss.preflightBackup(remoteConfig, dry); spaceErr != nil {
+			result = actionErrorOutput(label, spaceErr)
 		} else {
 			output, rpcErr := RcloneSync(fullRemotePath, fullLocalPath, dry)
 			if rpcErr != nil {
-				result = RcloneActionOutput{TargetFolder: label, CommandOutput: "", CommandError: rpcErr.Error()}
+				result = actionErrorOutput(label, rpcErr)
 			} else {
 				result = RcloneActionOutput{TargetFolder: label, CommandOutput: output, CommandError: ""}
 			}
 		}
 
-		emitEvent(EventTaskFolderComplete, TaskFolderCompletePayload{
-			TaskID:        taskID,
-			TargetFolder:  result.TargetFolder,
-			CommandOutput: result.CommandOutput,
-			CommandError:  result.CommandError,
+		emitEvent(EventTaskFolderComplete, newTaskFolderCompletePayload(taskID, result))
+		emitEvent(EventTaskComplete, TaskCompletePayload{TaskID: taskID})
+	}()
+	return nil
+}
+
+// projectPullJobs builds one job per folder for a whole-project pull. Registered folders that are
+// checked out locally are synced, so deletions only ever happen inside them; all other registered
+// folders are copied down. Unregistered remote directories, if requested, are only ever copied.
+// Nothing is ever synced at the bucket root.
+func (ss *SyncService) projectPullJobs(includeUnregistered bool, dry bool) ([]func() RcloneActionOutput, error) {
+	projectConfig := ss.configManager.GetProjectConfig()
+	if projectConfig == nil {
+		return nil, fmt.Errorf("project configuration is not loaded")
+	}
+	if !projectConfig.AllowGlobalSync {
+		return nil, fmt.Errorf("whole-project pulls are not allowed for this project; enable allow_global_sync in sync.json")
+	}
+	remoteConfig := ss.configManager.GetSelectedProjectRemoteConfig()
+	if remoteConfig == nil {
+		return nil, fmt.Errorf("selected project's remote configuration is not available")
+	}
+	if err := validateProjectFolderPaths(projectConfig); err != nil {
+		return nil, err
+	}
+	if report := validateProjectConfig(projectConfig); !report.Valid {
+		return nil, fmt.Errorf("the project configuration has problems; fix them before pulling the whole project: %s", report.Issues[0].Message)
+	}
+
+	var jobs []func() RcloneActionOutput
+	var folderKeys, unregisteredDirs []string
+	for folderKey, folderConfig := range projectConfig.Folders {
+		folderKeys = append(folderKeys, folderKey)
+		fullLocalPath, err := resolveFolderLocalPath(remoteConfig.LocalPath, folderKey, folderConfig)
+		if err != nil {
+			return nil, err
+		}
+		action := COPY_PULL
+		if _, err := os.Stat(fullLocalPath); err == nil {
+			action = SYNC_PULL
+		}
+		jobs = append(jobs, func() RcloneActionOutput {
+			return ss.executeSingleFolder(folderKey, action, dry)
 		})
+	}
+
+	if includeUnregistered {
+		drift, err := ss.DetectProjectDrift()
+		if err != nil {
+			return nil, err
+		}
+		for _, dir := range drift.UnregisteredRemoteFolders {
+			if err := validateFolderPath(dir); err != nil {
+				return nil, fmt.Errorf("unregistered remote folder has an invalid path: %v", err)
+			}
+			unregisteredDirs = append(unregisteredDirs, dir)
+			fullLocalPath := filepath.Join(remoteConfig.LocalPath, filepath.FromSlash(dir))
+			fullRemotePath := remoteConfig.remotePath(dir)
+			jobs = append(jobs, func() RcloneActionOutput {
+				output, rpcErr := RcloneCopy(fullRemotePath, fullLocalPath, dry)
+				if rpcErr != nil {
+					return actionErrorOutput(dir, rpcErr)
+				}
+				return RcloneActionOutput{TargetFolder: dir, CommandOutput: output, CommandError: ""}
+			})
+		}
+	}
+
+	// Make sure everything fits before the first byte is downloaded
+	if !dry {
+		if err := ss.preflightPullDiskSpace(folderKeys, unregisteredDirs); err != nil {
+			return nil, err
+		}
+	}
+
+	return jobs, nil
+}
+
+// ExecuteProjectPull pulls every registered folder, and optionally every unregistered remote
+// directory, down to the project's local path. Only allowed when the project enables AllowGlobalSync.
+func (ss *SyncService) ExecuteProjectPull(includeUnregistered bool, dry bool) []RcloneActionOutput {
+	jobs, err := ss.projectPullJobs(includeUnregistered, dry)
+	if err != nil {
+		label := ss.configManager.GetSelectedProject() + " - Project Pull"
+		return []RcloneActionOutput{actionErrorOutput(label, err)}
+	}
+
+	var outputs []RcloneActionOutput
+	var wg sync.WaitGroup
+	resultChan := make(chan RcloneActionOutput, len(jobs))
+
+	for _, job := range jobs {
+		wg.Add(1)
+		go func(job func() RcloneActionOutput) {
+			defer wg.Done()
+			resultChan <- job()
+		}(job)
+	}
+
+	wg.Wait()
+	close(resultChan)
+	for result := range resultChan {
+		outputs = append(outputs, result)
+	}
+
+	return outputs
+}
+
+// ExecuteProjectPullAsync runs the whole-project pull in the background, emitting a
+// "task-folder-complete" event per folder and a "task-complete" event when all are done.
+func (ss *SyncService) ExecuteProjectPullAsync(taskID string, includeUnregistered bool, dry bool) error {
+	go func() {
+		jobs, err := ss.projectPullJobs(includeUnregistered, dry)
+		if err != nil {
+			emitEvent(EventTaskFolderComplete, newTaskFolderCompletePayload(taskID, actionErrorOutput(ss.configManager.GetSelectedProject()+" - Project Pull", err)))
+			emitEvent(EventTaskComplete, TaskCompletePayload{TaskID: taskID})
+			return
+		}
+
+		var wg sync.WaitGroup
+		for _, job := range jobs {
+			wg.Add(1)
+			go func(job func() RcloneActionOutput) {
+				defer wg.Done()
+				result := job()
+				emitEvent(EventTaskFolderComplete, newTaskFolderCompletePayload(taskID, result))
+			}(job)
+		}
+
+		wg.Wait()
 		emitEvent(EventTaskComplete, TaskCompletePayload{TaskID: taskID})
 	}()
 	return nil
@@ -207,14 +400,18 @@ func (ss *SyncService) DetectChangedFolders(localFolders []string) []string {
 			continue
 		}
 		remoteConfig := ss.configManager.GetGlobalConfig().Remotes[ss.configManager.GetGlobalConfig().SelectedProject]
-		fullLocalPath := fmt.Sprintf("%s/%s", remoteConfig.LocalPath, folderConfig.LocalPath)
-		fullRemotePath := fmt.Sprintf("%s:%s/%s", remoteConfig.RemoteName, remoteConfig.BucketName, folderConfig.RemotePath)
+		fullLocalPath, fullRemotePath, err := resolveFolderPaths(&remoteConfig, folder, folderConfig)
+		if err != nil {
+			fmt.Printf("[WARN] detect changes skipped for %s: %v\n", folder, err)
+			continue
+		}
 
 		hasChanges, err := RcloneHasChanges(fullLocalPath, fullRemotePath)
 		if err != nil {
 			fmt.Printf("[WARN] detect changes failed for %s: %v\n", folder, err)
 			continue
 		}
+		ss.recordDirtyState(folder, hasChanges)
 		if hasChanges {
 			changedFolders = append(changedFolders, folder)
 		}
@@ -224,8 +421,22 @@ func (ss *SyncService) DetectChangedFolders(localFolders []string) []string {
 
 // DetectChangedFoldersAsync runs change detection in parallel, emitting per-folder events.
 // Emits "detect-folder-complete" for each folder and "detect-complete" when all done.
-func (ss *SyncService) DetectChangedFoldersAsync(taskID string, localFolders []string) error {
+// The folders of the named selection, if one is given, are checked as well.
+func (ss *SyncService) DetectChangedFoldersAsync(taskID string, localFolders []string, selectionName string) error {
 	go func() {
+		localFolders, err := resolveTargetFolders(ss.configManager.GetProjectConfig(), localFolders, selectionName)
+		if err != nil {
+			emitEvent(EventDetectFolderComplete, DetectFolderCompletePayload{
+				TaskID:       taskID,
+				TargetFolder: selectionName,
+				HasChanges:   false,
+				CommandError: err.Error(),
+				ErrorKind:    errorKind(err),
+			})
+			emitEvent(EventDetectComplete, DetectCompletePayload{TaskID: taskID})
+			return
+		}
+
 		var wg sync.WaitGroup
 
 		for _, f := range localFolders {
@@ -240,18 +451,31 @@ func (ss *SyncService) DetectChangedFoldersAsync(taskID string, localFolders []s
 						TargetFolder: f,
 						HasChanges:   false,
 						CommandError: fmt.Sprintf("folder config not found: %s", f),
+						ErrorKind:    ErrorKindNotFound,
 					})
 					return
 				}
 
 				remoteConfig := ss.configManager.GetGlobalConfig().Remotes[ss.configManager.GetGlobalConfig().SelectedProject]
-				fullLocalPath := fmt.Sprintf("%s/%s", remoteConfig.LocalPath, folderConfig.LocalPath)
-				fullRemotePath := fmt.Sprintf("%s:%s/%s", remoteConfig.RemoteName, remoteConfig.BucketName, folderConfig.RemotePath)
+				fullLocalPath, fullRemotePath, err := resolveFolderPaths(&remoteConfig, f, folderConfig)
+				if err != nil {
+					emitEvent(EventDetectFolderComplete, DetectFolderCompletePayload{
+						TaskID:       taskID,
+						TargetFolder: f,
+						HasChanges:   false,
+						CommandError: err.Error(),
+						ErrorKind:    errorKind(err),
+					})
+					return
+				}
 
 				hasChanges, err := RcloneHasChanges(fullLocalPath, fullRemotePath)
-				var cmdError string
+				var cmdError, cmdErrorKind string
 				if err != nil {
 					cmdError = err.Error()
+					cmdErrorKind = errorKind(err)
+				} else {
+					ss.recordDirtyState(f, hasChanges)
 				}
 
 				emitEvent(EventDetectFolderComplete, DetectFolderCompletePayload{
@@ -259,6 +483,7 @@ func (ss *SyncService) DetectChangedFoldersAsync(taskID string, localFolders []s
 					TargetFolder: f,
 					HasChanges:   hasChanges,
 					CommandError: cmdError,
+					ErrorKind:    cmdErrorKind,
 				})
 			}(f)
 		}
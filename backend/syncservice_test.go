@@ -0,0 +1,23 @@
+package backend
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestActionErrorOutputReportsDiskSpace(t *testing.T) {
+	diskErr := &InsufficientDiskSpaceError{Path: "/projects/demo", RequiredBytes: 2048, AvailableBytes: 1024}
+	output := actionErrorOutput("assets", fmt.Errorf("preflight: %w", diskErr))
+	if output.ErrorKind != ErrorKindDiskFull {
+		t.Errorf("ErrorKind = %q, want %q", output.ErrorKind, ErrorKindDiskFull)
+	}
+	if output.RequiredBytes != 2048 || output.AvailableBytes != 1024 {
+		t.Errorf("bytes = %d required, %d available, want 2048 and 1024", output.RequiredBytes, output.AvailableBytes)
+	}
+
+	output = actionErrorOutput("assets", errors.New("connection refused"))
+	if output.RequiredBytes != 0 || output.AvailableBytes != 0 {
+		t.Errorf("bytes = %d required, %d available for a network error, want 0", output.RequiredBytes, output.AvailableBytes)
+	}
+}
@@ -0,0 +1,119 @@
+package backend
+
+import (
+	"fmt"
+
+	"github.com/rclone/rclone/fs"
+)
+
+// TransferProfile tunes how a project's files are transferred. Zero values keep rclone's defaults.
+// Large files, like multi-GB EXR and cache files, upload much faster with larger chunks and more
+// streams, at the cost of memory.
+type TransferProfile struct {
+	Transfers          int    `json:"transfers"`            // Files transferred in parallel (rclone default 4)
+	Checkers           int    `json:"checkers"`             // Files compared in parallel (rclone default 8)
+	MultiThreadStreams int    `json:"multi_thread_streams"` // Streams per large file (rclone default 4)
+	UploadChunkSize    string `json:"upload_chunk_size"`    // Size of each part of a large upload, e.g. "96M"
+	BufferSize         string `json:"buffer_size"`          // Memory buffer per transfer, e.g. "64M" (rclone default 16M)
+	FastList           bool   `json:"fast_list"`            // List the remote in one recursive call; fewer API calls, more memory
+}
+
+// validate checks that the counts aren't negative and the sizes are rclone sizes.
+func (tp *TransferProfile) validate() error {
+	if tp.Transfers < 0 || tp.Checkers < 0 || tp.MultiThreadStreams < 0 {
+		return fmt.Errorf("transfers, checkers, and multi-thread streams can't be negative")
+	}
+	if err := validateTransferSize(tp.UploadChunkSize); err != nil {
+		return fmt.Errorf("upload chunk size: %v", err)
+	}
+	if err := validateTransferSize(tp.BufferSize); err != nil {
+		return fmt.Errorf("buffer size: %v", err)
+	}
+	return nil
+}
+
+// validateTransferSize checks that the size is empty or a positive rclone size.
+func validateTransferSize(size string) error {
+	if size == "" {
+		return nil
+	}
+	var parsed fs.SizeSuffix
+	if err := parsed.Set(size); err != nil {
+		return fmt.Errorf("invalid size '%s': %v", size, err)
+	}
+	if parsed <= 0 {
+		return fmt.Errorf("invalid size '%s': must be larger than 0", size)
+	}
+	return nil
+}
+
+// rcloneConfig returns the profile as the _config parameter of an rclone RPC call, which overrides
+// rclone's global options for that call only. Returns nil if the profile keeps every default. The
+// upload chunk size is a backend option, so it is set on the project's rclone remote instead. The
+// remote is recreated and rclone's cache of remotes is cleared whenever the profile changes, so
+// the new chunk size applies to the next transfer without a restart.
+func (tp *TransferProfile) rcloneConfig() map[string]interface{} {
+	config := map[string]interface{}{}
+	if tp.Transfers > 0 {
+		config["Transfers"] = tp.Transfers
+	}
+	if tp.Checkers > 0 {
+		config["Checkers"] = tp.Checkers
+	}
+	if tp.MultiThreadStreams > 0 {
+		config["MultiThreadStreams"] = tp.MultiThreadStreams
+		config["MultiThreadSet"] = true
+	}
+	if tp.BufferSize != "" {
+		config["BufferSize"] = tp.BufferSize
+	}
+	if tp.FastList {
+		config["UseListR"] = true
+	}
+	if len(config) == 0 {
+		return nil
+	}
+	return config
+}
+
+// supportsUploadChunkSize reports whether remotes of the given rclone backend type have a
+// chunk_size option, which the upload chunk size is set with.
+func supportsUploadChunkSize(remoteType string) bool {
+	regInfo, err := fs.Find(remoteType)
+	return err == nil && regInfo.Options.Get("chunk_size") != nil
+}
+
+// applyTransferProfile makes every following rclone RPC call use the selected project's
+// transfer profile.
+func (cm *ConfigManager) applyTransferProfile() {
+	var profile TransferProfile
+	if remoteConfig := cm.GetSelectedProjectRemoteConfig(); remoteConfig != nil {
+		profile = remoteConfig.TransferProfile
+	}
+	setRcloneTransferConfig(profile.rcloneConfig())
+}
+
+// SetProjectTransferProfile sets the transfer profile of a project. It applies to transfers that
+// start after the change.
+func (cs *ConfigService) SetProjectTransferProfile(projectName string, profile TransferProfile) (GlobalConfigView, error) {
+	view, err := cs.updateGlobalConfig(func(globalConfig *GlobalConfig, credentialStore CredentialStore) error {
+		remote, exists := globalConfig.Remotes[projectName]
+		if !exists {
+			return fmt.Errorf("project '%s' does not exist", projectName)
+		}
+		if err := profile.validate(); err != nil {
+			return err
+		}
+		if profile.UploadChunkSize != "" && !supportsUploadChunkSize(remote.Type) {
+			return fmt.Errorf("%s remotes don't support setting the upload chunk size", remote.Type)
+		}
+		remote.TransferProfile = profile
+		globalConfig.Remotes[projectName] = remote
+		return nil
+	})
+	if err != nil {
+		return view, err
+	}
+	cs.configManager.applyTransferProfile()
+	return view, nil
+}
@@ -0,0 +1,49 @@
+package backend
+
+import "testing"
+
+func TestTransferProfileValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		profile TransferProfile
+		wantErr bool
+	}{
+		{"defaults", TransferProfile{}, false},
+		{"tuned for large files", TransferProfile{Transfers: 8, Checkers: 16, MultiThreadStreams: 8, UploadChunkSize: "96M", BufferSize: "64M", FastList: true}, false},
+		{"plain byte sizes", TransferProfile{UploadChunkSize: "100000000", BufferSize: "1048576"}, false},
+		{"negative transfers", TransferProfile{Transfers: -1}, true},
+		{"negative checkers", TransferProfile{Checkers: -1}, true},
+		{"negative streams", TransferProfile{MultiThreadStreams: -2}, true},
+		{"invalid chunk size", TransferProfile{UploadChunkSize: "big"}, true},
+		{"zero chunk size", TransferProfile{UploadChunkSize: "0"}, true},
+		{"unlimited chunk size", TransferProfile{UploadChunkSize: "off"}, true},
+		{"invalid buffer size", TransferProfile{BufferSize: "12Q"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.profile.validate(); (err != nil) != tt.wantErr {
+				t.Errorf("validate() error = %v, want error %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSupportsUploadChunkSize(t *testing.T) {
+	initTestRclone()
+	tests := []struct {
+		remoteType string
+		want       bool
+	}{
+		{"b2", true},
+		{"s3", true},
+		{"drive", true},
+		{"local", false},
+		{"crypt", false},
+		{"no-such-backend", false},
+	}
+	for _, tt := range tests {
+		if got := supportsUploadChunkSize(tt.remoteType); got != tt.want {
+			t.Errorf("supportsUploadChunkSize(%q) = %v, want %v", tt.remoteType, got, tt.want)
+		}
+	}
+}
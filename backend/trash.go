@@ -0,0 +1,264 @@
+package backend
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"sort"
+	"time"
+)
+
+// trashDirName is the reserved directory at the remote project root that deleted folders are moved into.
+const trashDirName = ".trash"
+
+// defaultTrashRetentionDays is how long deleted folders are kept when no retention is given.
+const defaultTrashRetentionDays = 30
+
+// TrashEntry records a folder that was deleted from the remote. It keeps the folder's registration
+// so the folder can be restored exactly as it was.
+type TrashEntry struct {
+	FolderKey    string       `json:"folder_key"`
+	FolderConfig FolderConfig `json:"folder_config"`
+	TrashPath    string       `json:"trash_path"` // Relative to the remote project root
+	DeletedAt    string       `json:"deleted_at"` // RFC3339
+	DeletedBy    string       `json:"deleted_by"` // Host the deletion was made from
+}
+
+// DeleteRemoteFolder deletes a registered folder from the remote by moving its data into
+// .trash/<date>/ and deregistering it. The deletion is recorded in sync.json so any teammate can
+// restore it until it is purged. As a guard, confirmFolderKey must repeat the folder's key, and the
+// folder must not be checked out locally.
+func (fs *FolderService) DeleteRemoteFolder(folderKey string, confirmFolderKey string) (ProjectConfig, error) {
+	projectRemoteConfig, err := fs.getProjectRemoteConfig()
+	if err != nil {
+		return ProjectConfig{}, err
+	}
+	projectConfig, err := fs.getProjectConfig()
+	if err != nil {
+		return ProjectConfig{}, err
+	}
+
+	folderConfig, exists := projectConfig.Folders[folderKey]
+	if !exists {
+		return *projectConfig, fmt.Errorf("folder '%s' does not exist in the project configuration", folderKey)
+	}
+	if confirmFolderKey != folderKey {
+		return *projectConfig, fmt.Errorf("confirmation does not match folder '%s'; nothing was deleted", folderKey)
+	}
+
+	fullLocalPath, fullRemotePath, err := resolveFolderPaths(projectRemoteConfig, folderKey, folderConfig)
+	if err != nil {
+		return *projectConfig, err
+	}
+	if _, err := os.Stat(fullLocalPath); err == nil {
+		return *projectConfig, fmt.Errorf("folder '%s' is still checked out locally; remove the local copy before deleting it from the remote", folderKey)
+	}
+
+	// Pick a trash location that isn't taken yet, in case the same path was deleted earlier today
+	now := time.Now().UTC()
+	baseTrashPath := path.Join(trashDirName, now.Format("2006-01-02"), normalizePath(folderConfig.RemotePath))
+	trashPath := baseTrashPath
+	for attempt := 2; ; attempt++ {
+		existingFiles, err := remoteFileCount(projectRemoteConfig.remotePath(trashPath))
+		if err != nil {
+			return *projectConfig, fmt.Errorf("failed to check the trash: %v", err)
+		}
+		if existingFiles == 0 {
+			break
+		}
+		trashPath = fmt.Sprintf("%s~%d", baseTrashPath, attempt)
+	}
+
+	if err := RcloneMove(fullRemotePath, projectRemoteConfig.remotePath(trashPath)); err != nil {
+		return *projectConfig, fmt.Errorf("failed to move folder '%s' to the trash: %v", folderKey, err)
+	}
+
+	// Deregister the folder and record the deletion in a single write
+	hostname, _ := os.Hostname()
+	updatedConfig, err := fs.updateProjectConfig(func(latestConfig *ProjectConfig, projectRoot string) error {
+		if _, exists := latestConfig.Folders[folderKey]; !exists {
+			return fmt.Errorf("folder '%s' was removed from the project configuration while it was being deleted", folderKey)
+		}
+		delete(latestConfig.Folders, folderKey)
+		applyFolderOrder(latestConfig, sortedGroupFolders(latestConfig, folderConfig.Group))
+		replaceSelectionReferences(latestConfig, "folder", folderKey, "")
+		latestConfig.Trash = append(latestConfig.Trash, TrashEntry{
+			FolderKey:    folderKey,
+			FolderConfig: folderConfig,
+			TrashPath:    trashPath,
+			DeletedAt:    now.Format(time.RFC3339),
+			DeletedBy:    hostname,
+		})
+		return nil
+	})
+	if err != nil {
+		// Put the data back so the folder is still registered and intact
+		if undoErr := RcloneMove(projectRemoteConfig.remotePath(trashPath), fullRemotePath); undoErr != nil {
+			return updatedConfig, fmt.Errorf("%v; the folder's data is left in the trash at '%s': %v", err, trashPath, undoErr)
+		}
+	}
+	return updatedConfig, err
+}
+
+// ListTrash returns the folders in the trash, most recently deleted first.
+func (fs *FolderService) ListTrash() ([]TrashEntry, error) {
+	projectConfig, err := fs.getProjectConfig()
+	if err != nil {
+		return nil, err
+	}
+	entries := append([]TrashEntry{}, projectConfig.Trash...)
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].DeletedAt > entries[j].DeletedAt
+	})
+	return entries, nil
+}
+
+// RestoreFromTrash moves a trashed folder back to its original remote path and registers it again
+// under its original key.
+func (fs *FolderService) RestoreFromTrash(trashPath string) (ProjectConfig, error) {
+	projectRemoteConfig, err := fs.getProjectRemoteConfig()
+	if err != nil {
+		return ProjectConfig{}, err
+	}
+	projectConfig, err := fs.getProjectConfig()
+	if err != nil {
+		return ProjectConfig{}, err
+	}
+
+	entryIndex := findTrashEntry(projectConfig.Trash, trashPath)
+	if entryIndex < 0 {
+		return *projectConfig, fmt.Errorf("no trashed folder at '%s'", trashPath)
+	}
+	entry := projectConfig.Trash[entryIndex]
+	if err := validateTrashPath(entry.TrashPath); err != nil {
+		return *projectConfig, err
+	}
+
+	// The original key and paths must still be free
+	if _, exists := projectConfig.Folders[entry.FolderKey]; exists {
+		return *projectConfig, fmt.Errorf("a folder with the name '%s' is already configured; rename it before restoring", entry.FolderKey)
+	}
+	if err := checkFolderOverlap(projectConfig.Folders, entry.FolderKey, entry.FolderConfig, ""); err != nil {
+		return *projectConfig, err
+	}
+	_, fullRemotePath, err := resolveFolderPaths(projectRemoteConfig, entry.FolderKey, entry.FolderConfig)
+	if err != nil {
+		return *projectConfig, err
+	}
+	existingFiles, err := remoteFileCount(fullRemotePath)
+	if err != nil {
+		return *projectConfig, fmt.Errorf("failed to check the restore destination: %v", err)
+	}
+	if existingFiles > 0 {
+		return *projectConfig, fmt.Errorf("remote path '%s' already contains %d file(s)", fullRemotePath, existingFiles)
+	}
+
+	if err := RcloneMove(projectRemoteConfig.remotePath(entry.TrashPath), fullRemotePath); err != nil {
+		return *projectConfig, fmt.Errorf("failed to restore folder '%s' from the trash: %v", entry.FolderKey, err)
+	}
+
+	// Register the folder again, recreating its group if it was removed in the meantime
+	updatedConfig, err := fs.updateProjectConfig(func(latestConfig *ProjectConfig, projectRoot string) error {
+		entryIndex := findTrashEntry(latestConfig.Trash, trashPath)
+		if entryIndex < 0 {
+			return fmt.Errorf("trashed folder at '%s' was removed while it was being restored", trashPath)
+		}
+		if _, exists := latestConfig.Folders[entry.FolderKey]; exists {
+			return fmt.Errorf("a folder with the name '%s' is already configured; rename it before restoring", entry.FolderKey)
+		}
+		if err := checkFolderOverlap(latestConfig.Folders, entry.FolderKey, entry.FolderConfig, ""); err != nil {
+			return err
+		}
+		if _, groupExists := latestConfig.Groups[entry.FolderConfig.Group]; !groupExists {
+			entry.FolderConfig.Group = defaultGroupKey
+			if _, generalExists := latestConfig.Groups[defaultGroupKey]; !generalExists {
+				latestConfig.Groups[defaultGroupKey] = GroupConfig{Name: "General"}
+			}
+		}
+		latestConfig.Folders[entry.FolderKey] = entry.FolderConfig
+		placeFolder(latestConfig, entry.FolderKey, entry.FolderConfig.Group, entry.FolderConfig.Group, -1)
+		latestConfig.Trash = append(latestConfig.Trash[:entryIndex], latestConfig.Trash[entryIndex+1:]...)
+		return nil
+	})
+	if err != nil {
+		if undoErr := RcloneMove(fullRemotePath, projectRemoteConfig.remotePath(entry.TrashPath)); undoErr != nil {
+			return updatedConfig, fmt.Errorf("%v; the restored data was left at '%s': %v", err, fullRemotePath, undoErr)
+		}
+	}
+	return updatedConfig, err
+}
+
+// PurgeTrash permanently deletes trashed folders that were deleted more than retentionDays ago.
+// A retention of zero or less uses the default of 30 days. Returns the purged entries.
+func (fs *FolderService) PurgeTrash(retentionDays int) ([]TrashEntry, error) {
+	projectRemoteConfig, err := fs.getProjectRemoteConfig()
+	if err != nil {
+		return nil, err
+	}
+	projectConfig, err := fs.getProjectConfig()
+	if err != nil {
+		return nil, err
+	}
+	if retentionDays <= 0 {
+		retentionDays = defaultTrashRetentionDays
+	}
+	cutoff := time.Now().UTC().AddDate(0, 0, -retentionDays)
+
+	purged := []TrashEntry{}
+	purgedPaths := make(map[string]bool)
+	var purgeErr error
+	for _, entry := range projectConfig.Trash {
+		deletedAt, parseErr := time.Parse(time.RFC3339, entry.DeletedAt)
+		if parseErr != nil || deletedAt.After(cutoff) || purgeErr != nil || validateTrashPath(entry.TrashPath) != nil {
+			continue
+		}
+		if err := RclonePurge(projectRemoteConfig.remoteRoot(), entry.TrashPath); err != nil && errorKind(err) != ErrorKindNotFound {
+			// Keep this and every remaining entry; what was purged so far is still recorded below
+			purgeErr = fmt.Errorf("failed to purge '%s' from the trash: %v", entry.TrashPath, err)
+			continue
+		}
+		purged = append(purged, entry)
+		purgedPaths[entry.TrashPath] = true
+	}
+
+	if len(purged) > 0 {
+		_, err := fs.updateProjectConfig(func(latestConfig *ProjectConfig, projectRoot string) error {
+			remaining := []TrashEntry{}
+			for _, entry := range latestConfig.Trash {
+				if !purgedPaths[entry.TrashPath] {
+					remaining = append(remaining, entry)
+				}
+			}
+			latestConfig.Trash = remaining
+			return nil
+		})
+		if err != nil {
+			return purged, err
+		}
+	}
+	return purged, purgeErr
+}
+
+// validateTrashPath returns an error unless the path lies strictly inside the trash area. Trash
+// entries come from the shared sync.json, so this keeps a hand-edited entry from purging or moving
+// live project data.
+func validateTrashPath(trashPath string) error {
+	if err := validateRelativePath(trashPath); err != nil {
+		return fmt.Errorf("trash entry has an invalid path: %v", err)
+	}
+	cleaned := path.Clean(normalizePath(trashPath))
+	if cleaned == trashDirName || !isPathWithin(cleaned, trashDirName) {
+		return fmt.Errorf("trash entry '%s' is not inside the %s area", trashPath, trashDirName)
+	}
+	return nil
+}
+
+// findTrashEntry returns the index of the trash entry at the given path, or -1 if there is none.
+func findTrashEntry(entries []TrashEntry, trashPath string) int {
+	for i, entry := range entries {
+		if entry.TrashPath == trashPath {
+			return i
+		}
+	}
+	return -1
+}
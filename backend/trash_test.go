@@ -0,0 +1,90 @@
+package backend
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestValidateTrashPath(t *testing.T) {
+	tests := []struct {
+		trashPath string
+		wantErr   bool
+	}{
+		{".trash/2026-10-19/assets/textures", false},
+		{".trash/2026-10-19/assets~2", false},
+		{".trash", true},
+		{".trash/", true},
+		{"assets/textures", true},
+		{".trash/../assets", true},
+		{".trash/2026-10-19/../../assets", true},
+		{"../.trash/2026-10-19/assets", true},
+		{"/.trash/2026-10-19/assets", true},
+		{"C:/.trash/2026-10-19/assets", true},
+		{".trashcan/assets", true},
+	}
+	for _, tt := range tests {
+		if err := validateTrashPath(tt.trashPath); (err != nil) != tt.wantErr {
+			t.Errorf("validateTrashPath(%q) error = %v, want error %v", tt.trashPath, err, tt.wantErr)
+		}
+	}
+}
+
+func TestTrashGuards(t *testing.T) {
+	initTestRclone()
+	if err := RcloneCreateRemote("trashlocal", "local", map[string]string{}); err != nil {
+		t.Fatalf("failed to create local remote: %v", err)
+	}
+
+	bucketDir, localDir := t.TempDir(), t.TempDir()
+	modTime := time.Now().Add(-time.Hour)
+	writeTestFile(t, bucketDir, "assets/a.txt", "remote", modTime)
+	writeTestFile(t, bucketDir, "shots/a.txt", "remote", modTime)
+	writeTestFile(t, localDir, "assets/a.txt", "remote", modTime)
+
+	longAgo := time.Now().AddDate(-1, 0, 0).UTC().Format(time.RFC3339)
+	cm := NewConfigManager(&GlobalConfig{
+		SelectedProject: "demo",
+		Remotes: map[string]RemoteConfig{
+			"demo": {RemoteName: "trashlocal", BucketName: bucketDir, LocalPath: localDir},
+		},
+	}, &ProjectConfig{
+		Folders: map[string]FolderConfig{
+			"assets": {LocalPath: "assets", RemotePath: "assets"},
+		},
+		// A hand-edited entry pointing at live project data
+		Trash: []TrashEntry{{FolderKey: "shots", FolderConfig: FolderConfig{LocalPath: "shots", RemotePath: "shots"}, TrashPath: "shots", DeletedAt: longAgo}},
+	})
+	fs := NewFolderService(cm)
+	remoteExists := func(relPath string) bool {
+		_, err := os.Stat(filepath.Join(bucketDir, filepath.FromSlash(relPath)))
+		return err == nil
+	}
+
+	// A folder that is checked out locally can't be deleted from the remote
+	if _, err := fs.DeleteRemoteFolder("assets", "assets"); err == nil {
+		t.Errorf("DeleteRemoteFolder succeeded while the folder is checked out locally")
+	}
+	if !remoteExists("assets/a.txt") {
+		t.Errorf("the remote data was deleted despite the refusal")
+	}
+	if _, registered := cm.GetProjectConfig().Folders["assets"]; !registered {
+		t.Errorf("the folder was deregistered despite the refusal")
+	}
+
+	// Neither purge nor restore touches a trash entry outside the trash area
+	purged, err := fs.PurgeTrash(1)
+	if err != nil {
+		t.Fatalf("PurgeTrash failed: %v", err)
+	}
+	if len(purged) != 0 || !remoteExists("shots/a.txt") {
+		t.Errorf("PurgeTrash purged %+v outside the trash area", purged)
+	}
+	if _, err := fs.RestoreFromTrash("shots"); err == nil {
+		t.Errorf("RestoreFromTrash accepted an entry outside the trash area")
+	}
+	if !remoteExists("shots/a.txt") {
+		t.Errorf("RestoreFromTrash moved data outside the trash area")
+	}
+}
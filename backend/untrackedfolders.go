@@ -0,0 +1,170 @@
+package backend
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// untrackedScanDepth is how deep the project root is scanned for untracked folders at project load.
+const untrackedScanDepth = 2
+
+// localMetadataDirs are directories in the project root that belong to the app, not to the project.
+var localMetadataDirs = map[string]bool{
+	trashDirName: true,
+}
+
+// UntrackedFolder is a local directory that no registered folder covers, so it is never backed up.
+type UntrackedFolder struct {
+	LocalPath string `json:"local_path"` // Relative to the project root
+	FileCount int    `json:"file_count"`
+	Size      string `json:"size"`
+}
+
+// FindUntrackedLocalFolders walks the project root up to the given depth and reports the
+// shallowest directories that no registered folder covers, along with their sizes. Directories
+// that contain registered folders are descended into rather than reported.
+func (fs *FolderService) FindUntrackedLocalFolders(depth int) ([]UntrackedFolder, error) {
+	return fs.configManager.findUntrackedLocalFolders(depth)
+}
+
+// findUntrackedLocalFolders is shared by FolderService and the untracked folder warning at project load.
+func (cm *ConfigManager) findUntrackedLocalFolders(depth int) ([]UntrackedFolder, error) {
+	remoteConfig := cm.GetSelectedProjectRemoteConfig()
+	if remoteConfig == nil {
+		return nil, errors.New("selected project's remote configuration is not available")
+	}
+	projectConfig := cm.GetProjectConfig()
+	if projectConfig == nil {
+		return nil, errors.New("project configuration is not loaded")
+	}
+	if depth < 1 {
+		depth = 1
+	}
+
+	registeredPaths := make([]string, 0, len(projectConfig.Folders))
+	for _, folderConfig := range projectConfig.Folders {
+		registeredPaths = append(registeredPaths, cleanFolderPath(folderConfig.LocalPath))
+	}
+
+	untrackedFolders := []UntrackedFolder{}
+	var scanDir func(relDir string, level int) error
+	scanDir = func(relDir string, level int) error {
+		entries, err := os.ReadDir(filepath.Join(remoteConfig.LocalPath, filepath.FromSlash(relDir)))
+		if err != nil {
+			return fmt.Errorf("failed to read directory '%s': %v", relDir, err)
+		}
+		for _, entry := range entries {
+			if !entry.IsDir() || skipUntrackedDir(relDir, entry.Name()) {
+				continue
+			}
+			relPath := path.Join(relDir, entry.Name())
+
+			covered, containsRegistered := registeredCoverage(relPath, registeredPaths, localPathsFoldCase)
+			switch {
+			case covered:
+				continue
+			case containsRegistered:
+				if level < depth {
+					if err := scanDir(relPath, level+1); err != nil {
+						return err
+					}
+				}
+			default:
+				fileCount, size, err := measureLocalDir(filepath.Join(remoteConfig.LocalPath, filepath.FromSlash(relPath)))
+				if err != nil {
+					return err
+				}
+				untrackedFolders = append(untrackedFolders, UntrackedFolder{
+					LocalPath: relPath,
+					FileCount: fileCount,
+					Size:      formatSize(size),
+				})
+			}
+		}
+		return nil
+	}
+
+	if err := scanDir("", 1); err != nil {
+		return nil, err
+	}
+
+	sort.Slice(untrackedFolders, func(i, j int) bool {
+		return untrackedFolders[i].LocalPath < untrackedFolders[j].LocalPath
+	})
+	return untrackedFolders, nil
+}
+
+// registeredCoverage reports whether a local directory lies within a registered folder, and
+// otherwise whether it contains any. Case is ignored if foldCase is set, as folderPathOverlap
+// does for local paths.
+func registeredCoverage(relPath string, registeredPaths []string, foldCase bool) (covered bool, containsRegistered bool) {
+	for _, registeredPath := range registeredPaths {
+		if pathWithinFold(relPath, registeredPath, foldCase) {
+			return true, false
+		}
+		if pathWithinFold(registeredPath, relPath, foldCase) {
+			containsRegistered = true
+		}
+	}
+	return false, containsRegistered
+}
+
+// skipUntrackedDir reports whether a directory is left out of the untracked folder scan: the
+// app's own directories in the project root, and hidden directories like .git anywhere.
+func skipUntrackedDir(relDir string, name string) bool {
+	if relDir == "" && localMetadataDirs[name] {
+		return true
+	}
+	return strings.HasPrefix(name, ".")
+}
+
+// warnUntrackedLocalFolders scans the project root in the background and emits an
+// "untracked-folders" event if any untracked folders are found.
+func (cm *ConfigManager) warnUntrackedLocalFolders() {
+	selectedProject := cm.GetSelectedProject()
+	go func() {
+		untrackedFolders, err := cm.findUntrackedLocalFolders(untrackedScanDepth)
+		if err != nil {
+			fmt.Printf("[WARN] untracked folder scan failed: %v\n", err)
+			return
+		}
+		if len(untrackedFolders) == 0 {
+			return
+		}
+		emitEvent(EventUntrackedFolders, UntrackedFoldersPayload{
+			SelectedProject: selectedProject,
+			Folders:         untrackedFolders,
+		})
+	}()
+}
+
+// measureLocalDir returns the number of files in a local directory and their total size.
+func measureLocalDir(dir string) (int, int64, error) {
+	fileCount := 0
+	var size int64
+	err := filepath.WalkDir(dir, func(_ string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			return nil
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+		fileCount++
+		size += info.Size()
+		return nil
+	})
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to measure directory '%s': %v", dir, err)
+	}
+	return fileCount, size, nil
+}
@@ -0,0 +1,48 @@
+package backend
+
+import "testing"
+
+func TestSkipUntrackedDir(t *testing.T) {
+	tests := []struct {
+		relDir string
+		name   string
+		want   bool
+	}{
+		{"", trashDirName, true},
+		{"", ".git", true},
+		{"assets", ".cache", true},
+		{"", "assets", false},
+		{"assets", "trash", false},
+	}
+	for _, tt := range tests {
+		if got := skipUntrackedDir(tt.relDir, tt.name); got != tt.want {
+			t.Errorf("skipUntrackedDir(%q, %q) = %v, want %v", tt.relDir, tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestRegisteredCoverage(t *testing.T) {
+	registeredPaths := []string{"Assets", "shots/SEQ01"}
+	tests := []struct {
+		relPath            string
+		foldCase           bool
+		wantCovered        bool
+		wantContainsFolder bool
+	}{
+		{"Assets", false, true, false},
+		{"assets", false, false, false},
+		{"assets", true, true, false},
+		{"ASSETS/textures", true, true, false},
+		{"Shots", true, false, true},
+		{"Shots", false, false, false},
+		{"shots/seq01", true, true, false},
+		{"shots/seq02", true, false, false},
+	}
+	for _, tt := range tests {
+		covered, containsRegistered := registeredCoverage(tt.relPath, registeredPaths, tt.foldCase)
+		if covered != tt.wantCovered || containsRegistered != tt.wantContainsFolder {
+			t.Errorf("registeredCoverage(%q, foldCase=%v) = %v, %v; want %v, %v",
+				tt.relPath, tt.foldCase, covered, containsRegistered, tt.wantCovered, tt.wantContainsFolder)
+		}
+	}
+}
@@ -14,18 +14,49 @@ import { Call as $Call, CancellablePromise as $CancellablePromise, Create as $Cr
 // @ts-ignore: Unused imports
 import * as $models from "./models.js";
 
+/**
+ * AddProject adds a new project to the global configuration. The account and key are saved to
+ * the credential store; only a reference to them is kept in the configuration.
+ */
+export function AddProject(projectName: string, settings: $models.ProjectSettings, account: string, key: string): $CancellablePromise<$models.GlobalConfigView> {
+    return $Call.ByID(2879087374, projectName, settings, account, key).then(($result: any) => {
+        return $$createType0($result);
+    });
+}
+
+/**
+ * GetBandwidthRate returns the bandwidth limit currently applied to transfers, as an
+ * "upload:download" pair where "off" is unlimited.
+ */
+export function GetBandwidthRate(): $CancellablePromise<string> {
+    return $Call.ByID(369743769);
+}
+
+/**
+ * GetPendingConfigSync returns the projects whose sync.json changes haven't reached the remote yet.
+ */
+export function GetPendingConfigSync(): $CancellablePromise<$models.PendingConfigSync[]> {
+    return $Call.ByID(973884256).then(($result: any) => {
+        return $$createType2($result);
+    });
+}
+
+/**
+ * KeepLocalSyncFile resolves a conflict in favor of the selected project's local sync.json, by
+ * uploading it over the remote one. To keep the remote one instead, use RefreshSyncFile.
+ */
+export function KeepLocalSyncFile(): $CancellablePromise<void> {
+    return $Call.ByID(2149725639);
+}
+
 /**
  * Load the global configuration. This configuration determines what the Rclone remotes
- * are, and where their corresponding local project folders are found. Note that we return
- * the entire configuration object, in addition to the selected project string. The frontend
- * doesn't need the entire global configuration, so the selected project name should be
- * enough to allow user selection.
- * TODO Don't expose the application keys in the frontend; send only the remote names.
+ * are, and where their corresponding local project folders are found. Only the redacted
+ * view is returned, so no credentials or credential references ever reach the frontend.
  */
-export function LoadGlobalConfig(): $CancellablePromise<[$models.GlobalConfig, string]> {
+export function LoadGlobalConfig(): $CancellablePromise<$models.GlobalConfigView> {
     return $Call.ByID(1686438339).then(($result: any) => {
-        $result[0] = $$createType0($result[0]);
-        return $result;
+        return $$createType0($result);
     });
 }
 
@@ -36,7 +67,7 @@ export function LoadGlobalConfig(): $CancellablePromise<[$models.GlobalConfig, s
  */
 export function LoadSelectedProjectConfig(): $CancellablePromise<$models.ProjectConfig> {
     return $Call.ByID(3182534090).then(($result: any) => {
-        return $$createType1($result);
+        return $$createType3($result);
     });
 }
 
@@ -47,7 +78,79 @@ export function LoadSelectedProjectConfig(): $CancellablePromise<$models.Project
  */
 export function RefreshSyncFile(): $CancellablePromise<$models.ProjectConfig> {
     return $Call.ByID(3554693892).then(($result: any) => {
-        return $$createType1($result);
+        return $$createType3($result);
+    });
+}
+
+/**
+ * RemoveProject removes a project and its credentials from the global configuration. The local
+ * project folder and the remote bucket are left untouched.
+ */
+export function RemoveProject(projectName: string): $CancellablePromise<$models.GlobalConfigView> {
+    return $Call.ByID(2288333123, projectName).then(($result: any) => {
+        return $$createType0($result);
+    });
+}
+
+/**
+ * RetryPendingConfigSync retries every pending sync.json upload right away, without waiting for
+ * the backoff, and returns the uploads that are still pending afterwards. Uploads held back by a
+ * conflict stay pending.
+ */
+export function RetryPendingConfigSync(): $CancellablePromise<$models.PendingConfigSync[]> {
+    return $Call.ByID(2619070730).then(($result: any) => {
+        return $$createType2($result);
+    });
+}
+
+/**
+ * SetBandwidthLimit sets the bandwidth limit of every project without a limit of its own. The
+ * new limit applies to running transfers right away.
+ */
+export function SetBandwidthLimit(limit: $models.BandwidthLimit): $CancellablePromise<$models.GlobalConfigView> {
+    return $Call.ByID(1160193690, limit).then(($result: any) => {
+        return $$createType0($result);
+    });
+}
+
+/**
+ * SetProjectBandwidthLimit sets the bandwidth limit of a single project, replacing the global
+ * limit for it. A nil limit makes the project use the global limit again.
+ */
+export function SetProjectBandwidthLimit(projectName: string, limit: $models.BandwidthLimit | null): $CancellablePromise<$models.GlobalConfigView> {
+    return $Call.ByID(55979999, projectName, limit).then(($result: any) => {
+        return $$createType0($result);
+    });
+}
+
+/**
+ * SetProjectCredentials replaces the account and key of an existing project in the credential store.
+ */
+export function SetProjectCredentials(projectName: string, account: string, key: string): $CancellablePromise<$models.GlobalConfigView> {
+    return $Call.ByID(145890559, projectName, account, key).then(($result: any) => {
+        return $$createType0($result);
+    });
+}
+
+/**
+ * SetProjectEncryption turns client-side encryption on or off for a project. When enabled, the
+ * bucket is wrapped in an rclone crypt remote using the given password and optional salt, which
+ * are saved to the credential store. Existing unencrypted data in the bucket is not converted
+ * and won't be visible through the crypt remote.
+ */
+export function SetProjectEncryption(projectName: string, enabled: boolean, password: string, password2: string): $CancellablePromise<$models.GlobalConfigView> {
+    return $Call.ByID(4009583762, projectName, enabled, password, password2).then(($result: any) => {
+        return $$createType0($result);
+    });
+}
+
+/**
+ * SetProjectTransferProfile sets the transfer profile of a project. It applies to transfers that
+ * start after the change.
+ */
+export function SetProjectTransferProfile(projectName: string, profile: $models.TransferProfile): $CancellablePromise<$models.GlobalConfigView> {
+    return $Call.ByID(1691788849, projectName, profile).then(($result: any) => {
+        return $$createType0($result);
     });
 }
 
@@ -58,6 +161,17 @@ export function SetSelectedProject(selectedProject: string): $CancellablePromise
     return $Call.ByID(2749222836, selectedProject);
 }
 
+/**
+ * UpdateProject changes the non-secret settings of an existing project.
+ */
+export function UpdateProject(projectName: string, settings: $models.ProjectSettings): $CancellablePromise<$models.GlobalConfigView> {
+    return $Call.ByID(4279100012, projectName, settings).then(($result: any) => {
+        return $$createType0($result);
+    });
+}
+
 // Private type creation functions
-const $$createType0 = $models.GlobalConfig.createFrom;
-const $$createType1 = $models.ProjectConfig.createFrom;
+const $$createType0 = $models.GlobalConfigView.createFrom;
+const $$createType1 = $models.PendingConfigSync.createFrom;
+const $$createType2 = $Create.Array($$createType1);
+const $$createType3 = $models.ProjectConfig.createFrom;
@@ -10,11 +10,24 @@ import { Call as $Call, CancellablePromise as $CancellablePromise, Create as $Cr
 import * as $models from "./models.js";
 
 /**
- * CreateGroup creates a new group in the project configuration.
+ * ApplyConfigChanges applies a batch of changes as one transaction. The changes are applied in
+ * order to a copy of the project configuration, each seeing the result of the ones before it. If
+ * every change succeeds, the copy is saved and synced to the remote once; if any change fails,
+ * nothing is saved and the error names the failing change.
+ */
+export function ApplyConfigChanges(changes: $models.ConfigChange[]): $CancellablePromise<$models.ProjectConfig> {
+    return $Call.ByID(422154543, changes).then(($result: any) => {
+        return $$createType0($result);
+    });
+}
+
+/**
+ * CreateGroup creates a new group in the project configuration at the given position among its
+ * siblings; a negative index adds it after them. The sort order in groupConfig is ignored.
  * Returns the updated ProjectConfig or an error if the group already exists.
  */
-export function CreateGroup(groupKey: string, groupConfig: $models.GroupConfig): $CancellablePromise<$models.ProjectConfig> {
-    return $Call.ByID(2527921271, groupKey, groupConfig).then(($result: any) => {
+export function CreateGroup(groupKey: string, groupConfig: $models.GroupConfig, index: number): $CancellablePromise<$models.ProjectConfig> {
+    return $Call.ByID(2527921271, groupKey, groupConfig, index).then(($result: any) => {
         return $$createType0($result);
     });
 }
@@ -27,11 +40,14 @@ export function CreateLocalFolders(targetFolders: string[]): $CancellablePromise
 }
 
 /**
- * DeleteGroup removes a group from the project configuration.
- * Fails if the group contains folders or has child groups.
+ * DeleteGroup removes a group from the project configuration. By default it fails if the group
+ * contains folders or has child groups. In "reassign" mode, the group's folders and child groups
+ * are moved into targetGroup; in "cascade" mode, the group and all of its descendant groups are
+ * deleted and every folder in them is moved into targetGroup. Either way, everything is saved and
+ * synced in a single write.
  */
-export function DeleteGroup(groupKey: string): $CancellablePromise<$models.ProjectConfig> {
-    return $Call.ByID(492689746, groupKey).then(($result: any) => {
+export function DeleteGroup(groupKey: string, mode: string, targetGroup: string): $CancellablePromise<$models.ProjectConfig> {
+    return $Call.ByID(492689746, groupKey, mode, targetGroup).then(($result: any) => {
         return $$createType0($result);
     });
 }
@@ -43,10 +59,31 @@ export function DeleteLocalFolders(targetFolders: string[]): $CancellablePromise
     return $Call.ByID(1155014579, targetFolders);
 }
 
+/**
+ * DeleteRemoteFolder deletes a registered folder from the remote by moving its data into
+ * .trash/<date>/ and deregistering it. The deletion is recorded in sync.json so any teammate can
+ * restore it until it is purged. As a guard, confirmFolderKey must repeat the folder's key, and the
+ * folder must not be checked out locally.
+ */
+export function DeleteRemoteFolder(folderKey: string, confirmFolderKey: string): $CancellablePromise<$models.ProjectConfig> {
+    return $Call.ByID(4082642113, folderKey, confirmFolderKey).then(($result: any) => {
+        return $$createType0($result);
+    });
+}
+
+/**
+ * DeleteSelection removes the named folder selection. The folders themselves are not affected.
+ */
+export function DeleteSelection(selectionKey: string): $CancellablePromise<$models.ProjectConfig> {
+    return $Call.ByID(1705587695, selectionKey).then(($result: any) => {
+        return $$createType0($result);
+    });
+}
+
 /**
  * Given a target folder, scrub it out of the project configuration's folders. This does NOT delete the folder
- * locally nor remotely. It only untracks it. Full deletions should be carefully handled manually with Rclone
- * for now.
+ * locally nor remotely. It only untracks it. To also remove the remote data, use DeleteRemoteFolder, which
+ * moves it into the recoverable trash.
  */
 export function DeregisterFolder(targetFolder: string): $CancellablePromise<$models.ProjectConfig> {
     return $Call.ByID(1475157726, targetFolder).then(($result: any) => {
@@ -65,21 +102,86 @@ export function EditFolder(currentFolderName: string, newFolderName: string, new
 }
 
 /**
- * GetGroups returns all groups in the project configuration.
+ * FindUntrackedLocalFolders walks the project root up to the given depth and reports the
+ * shallowest directories that no registered folder covers, along with their sizes. Directories
+ * that contain registered folders are descended into rather than reported.
  */
-export function GetGroups(): $CancellablePromise<{ [_ in string]?: $models.GroupConfig }> {
-    return $Call.ByID(1582548756).then(($result: any) => {
+export function FindUntrackedLocalFolders(depth: number): $CancellablePromise<$models.UntrackedFolder[]> {
+    return $Call.ByID(2690235486, depth).then(($result: any) => {
         return $$createType2($result);
     });
 }
 
+/**
+ * GetGroups returns all groups in the project configuration as a tree, with groups and the folders
+ * inside each group in display order.
+ */
+export function GetGroups(): $CancellablePromise<$models.GroupTreeNode[]> {
+    return $Call.ByID(1582548756).then(($result: any) => {
+        return $$createType4($result);
+    });
+}
+
 /**
  * GetLocalFolders checks if the local paths for all folders in the ProjectConfig exist.
  * Returns a list of folder keys where the paths exist, or an error if something goes wrong.
  */
 export function GetLocalFolders(): $CancellablePromise<string[]> {
     return $Call.ByID(1746980032).then(($result: any) => {
-        return $$createType3($result);
+        return $$createType5($result);
+    });
+}
+
+/**
+ * ListTrash returns the folders in the trash, most recently deleted first.
+ */
+export function ListTrash(): $CancellablePromise<$models.TrashEntry[]> {
+    return $Call.ByID(406802482).then(($result: any) => {
+        return $$createType7($result);
+    });
+}
+
+/**
+ * MergeGroups merges the source group into the destination group: the source's folders and child
+ * groups are moved into the destination, selections that referenced the source now reference the
+ * destination, and the source group is removed. Everything is saved and synced in a single write.
+ */
+export function MergeGroups(sourceGroup: string, destinationGroup: string): $CancellablePromise<$models.ProjectConfig> {
+    return $Call.ByID(2188564788, sourceGroup, destinationGroup).then(($result: any) => {
+        return $$createType0($result);
+    });
+}
+
+/**
+ * MoveFolder moves a registered folder to a new path relative to the project root. The local
+ * directory is renamed, the remote prefix is moved server-side, and sync.json is updated. If any
+ * step fails, the steps already done are undone, so the folder is never left half moved.
+ */
+export function MoveFolder(folderKey: string, newPath: string): $CancellablePromise<$models.ProjectConfig> {
+    return $Call.ByID(4125548037, folderKey, newPath).then(($result: any) => {
+        return $$createType0($result);
+    });
+}
+
+/**
+ * MoveGroup moves a group, with everything below it, under a new parent at the given position
+ * among its new siblings. Use an empty parent to move it to the top level.
+ */
+export function MoveGroup(groupKey: string, newParent: string, index: number): $CancellablePromise<$models.ProjectConfig> {
+    return $Call.ByID(3177389684, groupKey, newParent, index).then(($result: any) => {
+        return $$createType0($result);
+    });
+}
+
+/**
+ * OffloadFolders frees local disk space by deleting the given folders locally, but only once the
+ * remote is verified to hold every local file. Each folder's local copy is compared against the
+ * remote by size and modification time; if any local file is missing from the remote or differs,
+ * the folder is kept and the unpushed files are listed in its result instead.
+ */
+export function OffloadFolders(targetFolders: string[]): $CancellablePromise<$models.OffloadResult[]> {
+    return $Call.ByID(3044583470, targetFolders).then(($result: any) => {
+        return $$createType9($result);
     });
 }
 
@@ -98,6 +200,28 @@ export function OpenFolderPicker(): $CancellablePromise<string> {
     return $Call.ByID(1001267190);
 }
 
+/**
+ * ProposeRemoteFolderRegistrations proposes a registration for every remote directory that isn't
+ * registered yet. Keys are taken from the directory names and groups from the parent paths, with
+ * any missing groups proposed as well. The proposal is meant to be reviewed and edited by the user
+ * before being passed to RegisterRemoteFolders.
+ */
+export function ProposeRemoteFolderRegistrations(): $CancellablePromise<$models.FolderRegistrationProposal> {
+    return $Call.ByID(4210576274).then(($result: any) => {
+        return $$createType10($result);
+    });
+}
+
+/**
+ * PurgeTrash permanently deletes trashed folders that were deleted more than retentionDays ago.
+ * A retention of zero or less uses the default of 30 days. Returns the purged entries.
+ */
+export function PurgeTrash(retentionDays: number): $CancellablePromise<$models.TrashEntry[]> {
+    return $Call.ByID(2248727643, retentionDays).then(($result: any) => {
+        return $$createType7($result);
+    });
+}
+
 /**
  * Given a new folder name and a new FolderConfig, create a new FolderConfig for it in the ProjectConfig.
  * Return the entire ProjectConfig after, which will contain the fully updated map of Folders.
@@ -108,6 +232,17 @@ export function RegisterNewFolder(newFolderName: string, folderConfig: $models.F
     });
 }
 
+/**
+ * RegisterRemoteFolders registers all given folders, and creates any new groups they need, in a
+ * single sync.json write. Unlike RegisterNewFolder, the folders don't need to exist locally. If
+ * any registration is invalid, nothing is registered.
+ */
+export function RegisterRemoteFolders(proposal: $models.FolderRegistrationProposal): $CancellablePromise<$models.ProjectConfig> {
+    return $Call.ByID(3582557736, proposal).then(($result: any) => {
+        return $$createType0($result);
+    });
+}
+
 /**
  * RenameGroup changes a group's key while preserving all folder assignments.
  */
@@ -118,17 +253,84 @@ export function RenameGroup(oldKey: string, newKey: string, newName: string): $C
 }
 
 /**
- * UpdateGroup updates an existing group's properties.
+ * ReorderFolders sets the order of the folders inside a group. orderedKeys must list every folder
+ * in the group exactly once.
+ */
+export function ReorderFolders(groupKey: string, orderedKeys: string[]): $CancellablePromise<$models.ProjectConfig> {
+    return $Call.ByID(1685058916, groupKey, orderedKeys).then(($result: any) => {
+        return $$createType0($result);
+    });
+}
+
+/**
+ * ReorderGroups sets the order of the parent's child groups. orderedKeys must list every child
+ * group of the parent exactly once. Use an empty parent for the top-level groups.
+ */
+export function ReorderGroups(parentKey: string, orderedKeys: string[]): $CancellablePromise<$models.ProjectConfig> {
+    return $Call.ByID(3675768627, parentKey, orderedKeys).then(($result: any) => {
+        return $$createType0($result);
+    });
+}
+
+/**
+ * ResolveSelection returns the keys of the folders currently in the named selection.
+ */
+export function ResolveSelection(selectionKey: string): $CancellablePromise<string[]> {
+    return $Call.ByID(1955867746, selectionKey).then(($result: any) => {
+        return $$createType5($result);
+    });
+}
+
+/**
+ * RestoreFromTrash moves a trashed folder back to its original remote path and registers it again
+ * under its original key.
+ */
+export function RestoreFromTrash(trashPath: string): $CancellablePromise<$models.ProjectConfig> {
+    return $Call.ByID(3807433292, trashPath).then(($result: any) => {
+        return $$createType0($result);
+    });
+}
+
+/**
+ * SaveSelection creates or replaces the named folder selection.
+ */
+export function SaveSelection(selectionKey: string, selection: $models.SelectionConfig): $CancellablePromise<$models.ProjectConfig> {
+    return $Call.ByID(3164631837, selectionKey, selection).then(($result: any) => {
+        return $$createType0($result);
+    });
+}
+
+/**
+ * UpdateGroup updates an existing group's properties and moves it to the given position among its
+ * siblings. A negative index keeps its current position, or adds it last under a new parent. The
+ * sort order in groupConfig is ignored.
  * Returns the updated ProjectConfig or an error if the group doesn't exist.
  */
-export function UpdateGroup(groupKey: string, groupConfig: $models.GroupConfig): $CancellablePromise<$models.ProjectConfig> {
-    return $Call.ByID(4248014072, groupKey, groupConfig).then(($result: any) => {
+export function UpdateGroup(groupKey: string, groupConfig: $models.GroupConfig, index: number): $CancellablePromise<$models.ProjectConfig> {
+    return $Call.ByID(4248014072, groupKey, groupConfig, index).then(($result: any) => {
         return $$createType0($result);
     });
 }
 
+/**
+ * ValidateProjectConfig reports every problem in the selected project's configuration.
+ */
+export function ValidateProjectConfig(): $CancellablePromise<$models.ProjectValidationReport> {
+    return $Call.ByID(2165357701).then(($result: any) => {
+        return $$createType11($result);
+    });
+}
+
 // Private type creation functions
 const $$createType0 = $models.ProjectConfig.createFrom;
-const $$createType1 = $models.GroupConfig.createFrom;
-const $$createType2 = $Create.Map($Create.Any, $$createType1);
-const $$createType3 = $Create.Array($Create.Any);
+const $$createType1 = $models.UntrackedFolder.createFrom;
+const $$createType2 = $Create.Array($$createType1);
+const $$createType3 = $models.GroupTreeNode.createFrom;
+const $$createType4 = $Create.Array($$createType3);
+const $$createType5 = $Create.Array($Create.Any);
+const $$createType6 = $models.TrashEntry.createFrom;
+const $$createType7 = $Create.Array($$createType6);
+const $$createType8 = $models.OffloadResult.createFrom;
+const $$createType9 = $Create.Array($$createType8);
+const $$createType10 = $models.FolderRegistrationProposal.createFrom;
+const $$createType11 = $models.ProjectValidationReport.createFrom;
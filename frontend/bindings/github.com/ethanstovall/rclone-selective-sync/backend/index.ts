@@ -11,11 +11,32 @@ export {
 };
 
 export {
+    BandwidthLimit,
+    BandwidthScheduleEntry,
+    ConfigChange,
+    DiffEntry,
     FolderConfig,
-    GlobalConfig,
+    FolderRegistration,
+    FolderRegistrationProposal,
+    FolderStatus,
+    GlobalConfigView,
+    GroupChangeSummary,
     GroupConfig,
+    GroupTreeNode,
+    OffloadResult,
+    PendingConfigSync,
     ProjectConfig,
+    ProjectDrift,
+    ProjectSettings,
+    ProjectSummary,
+    ProjectValidationIssue,
+    ProjectValidationReport,
     RcloneAction,
     RcloneActionOutput,
-    RemoteConfig
+    RemoteFileState,
+    RemoteFolderSummary,
+    SelectionConfig,
+    TransferProfile,
+    TrashEntry,
+    UntrackedFolder
 } from "./models.js";
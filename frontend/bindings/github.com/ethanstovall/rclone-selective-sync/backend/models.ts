@@ -5,98 +5,281 @@
 // @ts-ignore: Unused imports
 import { Create as $Create } from "@wailsio/runtime";
 
-export class FolderConfig {
-    "remote_path": string;
-    "local_path": string;
-    "description": string;
+/**
+ * BandwidthLimit caps how fast transfers upload and download. Rates use rclone's size syntax, so
+ * "5M" is 5 MiB/s and "512k" is 512 KiB/s; an empty rate or "off" is unlimited.
+ */
+export class BandwidthLimit {
+    "upload": string;
+    "download": string;
 
     /**
-     * Group key (required for new folders)
+     * Replaces Upload and Download when not empty
      */
-    "group": string;
+    "schedule": BandwidthScheduleEntry[];
 
-    /** Creates a new FolderConfig instance. */
-    constructor($$source: Partial<FolderConfig> = {}) {
-        if (!("remote_path" in $$source)) {
-            this["remote_path"] = "";
+    /** Creates a new BandwidthLimit instance. */
+    constructor($$source: Partial<BandwidthLimit> = {}) {
+        if (!("upload" in $$source)) {
+            this["upload"] = "";
         }
-        if (!("local_path" in $$source)) {
-            this["local_path"] = "";
+        if (!("download" in $$source)) {
+            this["download"] = "";
         }
-        if (!("description" in $$source)) {
-            this["description"] = "";
+        if (!("schedule" in $$source)) {
+            this["schedule"] = [];
         }
-        if (!("group" in $$source)) {
-            this["group"] = "";
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new BandwidthLimit instance from a string or object.
+     */
+    static createFrom($$source: any = {}): BandwidthLimit {
+        const $$createField2_0 = $$createType1;
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        if ("schedule" in $$parsedSource) {
+            $$parsedSource["schedule"] = $$createField2_0($$parsedSource["schedule"]);
+        }
+        return new BandwidthLimit($$parsedSource as Partial<BandwidthLimit>);
+    }
+}
+
+/**
+ * BandwidthScheduleEntry sets the rates from its start time until the next entry starts, like an
+ * rclone bwlimit timetable. "5M during office hours, unlimited at night" is an entry starting at
+ * 09:00 with 5M rates followed by one starting at 18:00 with empty rates.
+ */
+export class BandwidthScheduleEntry {
+    /**
+     * "mon" to "sun"; empty for every day
+     */
+    "days": string[];
+
+    /**
+     * "HH:MM" in local time
+     */
+    "start": string;
+    "upload": string;
+    "download": string;
+
+    /** Creates a new BandwidthScheduleEntry instance. */
+    constructor($$source: Partial<BandwidthScheduleEntry> = {}) {
+        if (!("days" in $$source)) {
+            this["days"] = [];
+        }
+        if (!("start" in $$source)) {
+            this["start"] = "";
+        }
+        if (!("upload" in $$source)) {
+            this["upload"] = "";
+        }
+        if (!("download" in $$source)) {
+            this["download"] = "";
         }
 
         Object.assign(this, $$source);
     }
 
     /**
-     * Creates a new FolderConfig instance from a string or object.
+     * Creates a new BandwidthScheduleEntry instance from a string or object.
      */
-    static createFrom($$source: any = {}): FolderConfig {
+    static createFrom($$source: any = {}): BandwidthScheduleEntry {
+        const $$createField0_0 = $$createType2;
         let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
-        return new FolderConfig($$parsedSource as Partial<FolderConfig>);
+        if ("days" in $$parsedSource) {
+            $$parsedSource["days"] = $$createField0_0($$parsedSource["days"]);
+        }
+        return new BandwidthScheduleEntry($$parsedSource as Partial<BandwidthScheduleEntry>);
     }
 }
 
-export class GlobalConfig {
-    "selected_project": string;
-    "remotes": { [_ in string]?: RemoteConfig };
+/**
+ * ConfigChange is a single project configuration edit in a batch passed to ApplyConfigChanges.
+ * Only the fields used by its Op are read.
+ */
+export class ConfigChange {
+    "op": string;
+    "folder_key": string;
+    "new_folder_key": string;
+    "folder": FolderConfig;
+    "group_key": string;
+    "new_group_key": string;
+    "name": string;
+    "group": GroupConfig;
+    "target_group": string;
+    "mode": string;
+    "index": number;
+    "ordered_keys": string[];
+    "selection_key": string;
+    "selection": SelectionConfig;
 
-    /** Creates a new GlobalConfig instance. */
-    constructor($$source: Partial<GlobalConfig> = {}) {
-        if (!("selected_project" in $$source)) {
-            this["selected_project"] = "";
+    /** Creates a new ConfigChange instance. */
+    constructor($$source: Partial<ConfigChange> = {}) {
+        if (!("op" in $$source)) {
+            this["op"] = "";
+        }
+        if (!("folder_key" in $$source)) {
+            this["folder_key"] = "";
+        }
+        if (!("new_folder_key" in $$source)) {
+            this["new_folder_key"] = "";
+        }
+        if (!("folder" in $$source)) {
+            this["folder"] = (new FolderConfig());
+        }
+        if (!("group_key" in $$source)) {
+            this["group_key"] = "";
+        }
+        if (!("new_group_key" in $$source)) {
+            this["new_group_key"] = "";
+        }
+        if (!("name" in $$source)) {
+            this["name"] = "";
+        }
+        if (!("group" in $$source)) {
+            this["group"] = (new GroupConfig());
+        }
+        if (!("target_group" in $$source)) {
+            this["target_group"] = "";
+        }
+        if (!("mode" in $$source)) {
+            this["mode"] = "";
+        }
+        if (!("index" in $$source)) {
+            this["index"] = 0;
+        }
+        if (!("ordered_keys" in $$source)) {
+            this["ordered_keys"] = [];
         }
-        if (!("remotes" in $$source)) {
-            this["remotes"] = {};
+        if (!("selection_key" in $$source)) {
+            this["selection_key"] = "";
+        }
+        if (!("selection" in $$source)) {
+            this["selection"] = (new SelectionConfig());
         }
 
         Object.assign(this, $$source);
     }
 
     /**
-     * Creates a new GlobalConfig instance from a string or object.
+     * Creates a new ConfigChange instance from a string or object.
      */
-    static createFrom($$source: any = {}): GlobalConfig {
-        const $$createField1_0 = $$createType1;
+    static createFrom($$source: any = {}): ConfigChange {
+        const $$createField3_0 = $$createType3;
+        const $$createField7_0 = $$createType4;
+        const $$createField11_0 = $$createType2;
+        const $$createField13_0 = $$createType5;
         let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
-        if ("remotes" in $$parsedSource) {
-            $$parsedSource["remotes"] = $$createField1_0($$parsedSource["remotes"]);
+        if ("folder" in $$parsedSource) {
+            $$parsedSource["folder"] = $$createField3_0($$parsedSource["folder"]);
+        }
+        if ("group" in $$parsedSource) {
+            $$parsedSource["group"] = $$createField7_0($$parsedSource["group"]);
+        }
+        if ("ordered_keys" in $$parsedSource) {
+            $$parsedSource["ordered_keys"] = $$createField11_0($$parsedSource["ordered_keys"]);
+        }
+        if ("selection" in $$parsedSource) {
+            $$parsedSource["selection"] = $$createField13_0($$parsedSource["selection"]);
         }
-        return new GlobalConfig($$parsedSource as Partial<GlobalConfig>);
+        return new ConfigChange($$parsedSource as Partial<ConfigChange>);
     }
 }
 
 /**
- * GroupConfig defines a folder group for organizing folders in the UI
+ * DiffEntry represents a single file change in a diff.
  */
-export class GroupConfig {
+export class DiffEntry {
     /**
-     * Display name
+     * "add", "update", "delete"
      */
-    "name": string;
+    "type": string;
 
     /**
-     * Empty = top-level, otherwise = nested under parent
+     * file path
      */
-    "parent_group": string;
+    "path": string;
+
+    /**
+     * human-readable size
+     */
+    "size": string;
+
+    /**
+     * previous size (for updates with size change)
+     */
+    "oldSize": string;
+
+    /**
+     * additional detail (e.g. "modified")
+     */
+    "detail": string;
+
+    /** Creates a new DiffEntry instance. */
+    constructor($$source: Partial<DiffEntry> = {}) {
+        if (!("type" in $$source)) {
+            this["type"] = "";
+        }
+        if (!("path" in $$source)) {
+            this["path"] = "";
+        }
+        if (!("size" in $$source)) {
+            this["size"] = "";
+        }
+        if (!("oldSize" in $$source)) {
+            this["oldSize"] = "";
+        }
+        if (!("detail" in $$source)) {
+            this["detail"] = "";
+        }
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new DiffEntry instance from a string or object.
+     */
+    static createFrom($$source: any = {}): DiffEntry {
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        return new DiffEntry($$parsedSource as Partial<DiffEntry>);
+    }
+}
+
+export class FolderConfig {
+    "remote_path": string;
+    "local_path": string;
+    "description": string;
+
+    /**
+     * Group key (required for new folders)
+     */
+    "group": string;
+
+    /**
+     * Free-form tags, for selections that cut across groups
+     */
+    "tags"?: string[];
 
     /**
-     * For manual ordering (future)
+     * Position among the folders in the same group
      */
     "sort_order": number;
 
-    /** Creates a new GroupConfig instance. */
-    constructor($$source: Partial<GroupConfig> = {}) {
-        if (!("name" in $$source)) {
-            this["name"] = "";
+    /** Creates a new FolderConfig instance. */
+    constructor($$source: Partial<FolderConfig> = {}) {
+        if (!("remote_path" in $$source)) {
+            this["remote_path"] = "";
         }
-        if (!("parent_group" in $$source)) {
-            this["parent_group"] = "";
+        if (!("local_path" in $$source)) {
+            this["local_path"] = "";
+        }
+        if (!("description" in $$source)) {
+            this["description"] = "";
+        }
+        if (!("group" in $$source)) {
+            this["group"] = "";
         }
         if (!("sort_order" in $$source)) {
             this["sort_order"] = 0;
@@ -106,26 +289,66 @@ export class GroupConfig {
     }
 
     /**
-     * Creates a new GroupConfig instance from a string or object.
+     * Creates a new FolderConfig instance from a string or object.
      */
-    static createFrom($$source: any = {}): GroupConfig {
+    static createFrom($$source: any = {}): FolderConfig {
+        const $$createField4_0 = $$createType2;
         let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
-        return new GroupConfig($$parsedSource as Partial<GroupConfig>);
+        if ("tags" in $$parsedSource) {
+            $$parsedSource["tags"] = $$createField4_0($$parsedSource["tags"]);
+        }
+        return new FolderConfig($$parsedSource as Partial<FolderConfig>);
     }
 }
 
-export class ProjectConfig {
-    "allow_global_sync": boolean;
-    "folders": { [_ in string]?: FolderConfig };
-    "groups": { [_ in string]?: GroupConfig };
+/**
+ * FolderRegistration is a folder to register under the given key.
+ */
+export class FolderRegistration {
+    "folder_key": string;
+    "folder_config": FolderConfig;
 
-    /** Creates a new ProjectConfig instance. */
-    constructor($$source: Partial<ProjectConfig> = {}) {
-        if (!("allow_global_sync" in $$source)) {
-            this["allow_global_sync"] = false;
+    /** Creates a new FolderRegistration instance. */
+    constructor($$source: Partial<FolderRegistration> = {}) {
+        if (!("folder_key" in $$source)) {
+            this["folder_key"] = "";
+        }
+        if (!("folder_config" in $$source)) {
+            this["folder_config"] = (new FolderConfig());
+        }
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new FolderRegistration instance from a string or object.
+     */
+    static createFrom($$source: any = {}): FolderRegistration {
+        const $$createField1_0 = $$createType3;
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        if ("folder_config" in $$parsedSource) {
+            $$parsedSource["folder_config"] = $$createField1_0($$parsedSource["folder_config"]);
         }
+        return new FolderRegistration($$parsedSource as Partial<FolderRegistration>);
+    }
+}
+
+/**
+ * FolderRegistrationProposal is a set of folders to register in one go, along with any new groups
+ * they need.
+ */
+export class FolderRegistrationProposal {
+    "folders": FolderRegistration[];
+
+    /**
+     * New groups to create
+     */
+    "groups": { [_ in string]?: GroupConfig };
+
+    /** Creates a new FolderRegistrationProposal instance. */
+    constructor($$source: Partial<FolderRegistrationProposal> = {}) {
         if (!("folders" in $$source)) {
-            this["folders"] = {};
+            this["folders"] = [];
         }
         if (!("groups" in $$source)) {
             this["groups"] = {};
@@ -135,111 +358,1167 @@ export class ProjectConfig {
     }
 
     /**
-     * Creates a new ProjectConfig instance from a string or object.
+     * Creates a new FolderRegistrationProposal instance from a string or object.
      */
-    static createFrom($$source: any = {}): ProjectConfig {
-        const $$createField1_0 = $$createType3;
-        const $$createField2_0 = $$createType5;
+    static createFrom($$source: any = {}): FolderRegistrationProposal {
+        const $$createField0_0 = $$createType7;
+        const $$createField1_0 = $$createType8;
         let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
         if ("folders" in $$parsedSource) {
-            $$parsedSource["folders"] = $$createField1_0($$parsedSource["folders"]);
+            $$parsedSource["folders"] = $$createField0_0($$parsedSource["folders"]);
         }
         if ("groups" in $$parsedSource) {
-            $$parsedSource["groups"] = $$createField2_0($$parsedSource["groups"]);
+            $$parsedSource["groups"] = $$createField1_0($$parsedSource["groups"]);
         }
-        return new ProjectConfig($$parsedSource as Partial<ProjectConfig>);
+        return new FolderRegistrationProposal($$parsedSource as Partial<FolderRegistrationProposal>);
     }
 }
 
-export enum RcloneAction {
+/**
+ * FolderStatus is everything the dashboard shows about one registered folder.
+ */
+export class FolderStatus {
+    "folder_key": string;
+    "exists_locally": boolean;
+    "local_size": number;
+    "local_file_count": number;
+
     /**
-     * The Go zero value for the underlying type of the enum.
+     * As of RemoteCheckedAt
      */
-    $zero = "",
+    "remote_size": number;
 
-    SYNC_PUSH = "SYNC_PUSH",
-    SYNC_PULL = "SYNC_PULL",
-    COPY_PULL = "COPY_PULL",
-};
+    /**
+     * As of RemoteCheckedAt
+     */
+    "remote_file_count": number;
 
-export class RcloneActionOutput {
-    "target_folder": string;
-    "command_output": string;
-    "command_error": string;
+    /**
+     * RFC3339; empty if the remote was never measured
+     */
+    "remote_checked_at": string;
 
-    /** Creates a new RcloneActionOutput instance. */
-    constructor($$source: Partial<RcloneActionOutput> = {}) {
-        if (!("target_folder" in $$source)) {
-            this["target_folder"] = "";
+    /**
+     * RFC3339; last successful push from this machine
+     */
+    "last_push": string;
+
+    /**
+     * RFC3339; last successful pull to this machine
+     */
+    "last_pull": string;
+
+    /**
+     * "clean", "dirty", or "unknown"
+     */
+    "dirty_state": string;
+
+    /**
+     * RFC3339; when the dirty state was last determined
+     */
+    "dirty_checked_at": string;
+    "error": string;
+
+    /** Creates a new FolderStatus instance. */
+    constructor($$source: Partial<FolderStatus> = {}) {
+        if (!("folder_key" in $$source)) {
+            this["folder_key"] = "";
         }
-        if (!("command_output" in $$source)) {
-            this["command_output"] = "";
+        if (!("exists_locally" in $$source)) {
+            this["exists_locally"] = false;
         }
-        if (!("command_error" in $$source)) {
-            this["command_error"] = "";
+        if (!("local_size" in $$source)) {
+            this["local_size"] = 0;
+        }
+        if (!("local_file_count" in $$source)) {
+            this["local_file_count"] = 0;
+        }
+        if (!("remote_size" in $$source)) {
+            this["remote_size"] = 0;
+        }
+        if (!("remote_file_count" in $$source)) {
+            this["remote_file_count"] = 0;
+        }
+        if (!("remote_checked_at" in $$source)) {
+            this["remote_checked_at"] = "";
+        }
+        if (!("last_push" in $$source)) {
+            this["last_push"] = "";
+        }
+        if (!("last_pull" in $$source)) {
+            this["last_pull"] = "";
+        }
+        if (!("dirty_state" in $$source)) {
+            this["dirty_state"] = "";
+        }
+        if (!("dirty_checked_at" in $$source)) {
+            this["dirty_checked_at"] = "";
+        }
+        if (!("error" in $$source)) {
+            this["error"] = "";
         }
 
         Object.assign(this, $$source);
     }
 
     /**
-     * Creates a new RcloneActionOutput instance from a string or object.
+     * Creates a new FolderStatus instance from a string or object.
      */
-    static createFrom($$source: any = {}): RcloneActionOutput {
+    static createFrom($$source: any = {}): FolderStatus {
         let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
-        return new RcloneActionOutput($$parsedSource as Partial<RcloneActionOutput>);
+        return new FolderStatus($$parsedSource as Partial<FolderStatus>);
     }
 }
 
-export class RemoteConfig {
-    "remote_name": string;
-    "bucket_name": string;
-    "type": string;
-    "account": string;
-    "key": string;
-    "local_path": string;
-    "full_backup_path": string;
+/**
+ * GlobalConfigView is the redacted view of the GlobalConfig that is sent to the frontend.
+ */
+export class GlobalConfigView {
+    "selected_project": string;
 
-    /** Creates a new RemoteConfig instance. */
-    constructor($$source: Partial<RemoteConfig> = {}) {
-        if (!("remote_name" in $$source)) {
-            this["remote_name"] = "";
+    /**
+     * Sorted by name
+     */
+    "projects": ProjectSummary[];
+    "bandwidth_limit": BandwidthLimit;
+
+    /** Creates a new GlobalConfigView instance. */
+    constructor($$source: Partial<GlobalConfigView> = {}) {
+        if (!("selected_project" in $$source)) {
+            this["selected_project"] = "";
         }
-        if (!("bucket_name" in $$source)) {
-            this["bucket_name"] = "";
+        if (!("projects" in $$source)) {
+            this["projects"] = [];
         }
-        if (!("type" in $$source)) {
-            this["type"] = "";
+        if (!("bandwidth_limit" in $$source)) {
+            this["bandwidth_limit"] = (new BandwidthLimit());
         }
-        if (!("account" in $$source)) {
-            this["account"] = "";
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new GlobalConfigView instance from a string or object.
+     */
+    static createFrom($$source: any = {}): GlobalConfigView {
+        const $$createField1_0 = $$createType10;
+        const $$createField2_0 = $$createType11;
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        if ("projects" in $$parsedSource) {
+            $$parsedSource["projects"] = $$createField1_0($$parsedSource["projects"]);
         }
-        if (!("key" in $$source)) {
-            this["key"] = "";
+        if ("bandwidth_limit" in $$parsedSource) {
+            $$parsedSource["bandwidth_limit"] = $$createField2_0($$parsedSource["bandwidth_limit"]);
         }
-        if (!("local_path" in $$source)) {
+        return new GlobalConfigView($$parsedSource as Partial<GlobalConfigView>);
+    }
+}
+
+/**
+ * GroupChangeSummary rolls up change detection for one group. The counts include every folder in
+ * the group's descendant groups.
+ */
+export class GroupChangeSummary {
+    "group_key": string;
+    "parent_group": string;
+
+    /**
+     * Registered folders
+     */
+    "folder_count": number;
+
+    /**
+     * Folders checked out locally, which are the ones checked for changes
+     */
+    "local_folder_count": number;
+
+    /**
+     * Local folders that differ from the remote
+     */
+    "changed_folder_count": number;
+
+    /**
+     * Changed folders directly in this group
+     */
+    "changed_folders": string[];
+    "errors": string[];
+
+    /** Creates a new GroupChangeSummary instance. */
+    constructor($$source: Partial<GroupChangeSummary> = {}) {
+        if (!("group_key" in $$source)) {
+            this["group_key"] = "";
+        }
+        if (!("parent_group" in $$source)) {
+            this["parent_group"] = "";
+        }
+        if (!("folder_count" in $$source)) {
+            this["folder_count"] = 0;
+        }
+        if (!("local_folder_count" in $$source)) {
+            this["local_folder_count"] = 0;
+        }
+        if (!("changed_folder_count" in $$source)) {
+            this["changed_folder_count"] = 0;
+        }
+        if (!("changed_folders" in $$source)) {
+            this["changed_folders"] = [];
+        }
+        if (!("errors" in $$source)) {
+            this["errors"] = [];
+        }
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new GroupChangeSummary instance from a string or object.
+     */
+    static createFrom($$source: any = {}): GroupChangeSummary {
+        const $$createField5_0 = $$createType2;
+        const $$createField6_0 = $$createType2;
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        if ("changed_folders" in $$parsedSource) {
+            $$parsedSource["changed_folders"] = $$createField5_0($$parsedSource["changed_folders"]);
+        }
+        if ("errors" in $$parsedSource) {
+            $$parsedSource["errors"] = $$createField6_0($$parsedSource["errors"]);
+        }
+        return new GroupChangeSummary($$parsedSource as Partial<GroupChangeSummary>);
+    }
+}
+
+/**
+ * GroupConfig defines a folder group for organizing folders in the UI
+ */
+export class GroupConfig {
+    /**
+     * Display name
+     */
+    "name": string;
+
+    /**
+     * Empty = top-level, otherwise = nested under parent
+     */
+    "parent_group": string;
+
+    /**
+     * Position among the groups with the same parent
+     */
+    "sort_order": number;
+
+    /** Creates a new GroupConfig instance. */
+    constructor($$source: Partial<GroupConfig> = {}) {
+        if (!("name" in $$source)) {
+            this["name"] = "";
+        }
+        if (!("parent_group" in $$source)) {
+            this["parent_group"] = "";
+        }
+        if (!("sort_order" in $$source)) {
+            this["sort_order"] = 0;
+        }
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new GroupConfig instance from a string or object.
+     */
+    static createFrom($$source: any = {}): GroupConfig {
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        return new GroupConfig($$parsedSource as Partial<GroupConfig>);
+    }
+}
+
+/**
+ * GroupTreeNode is a group with its folders and child groups, all in display order.
+ */
+export class GroupTreeNode {
+    "key": string;
+    "name": string;
+    "parent_group": string;
+    "sort_order": number;
+    "folders": string[];
+    "children": GroupTreeNode[];
+
+    /** Creates a new GroupTreeNode instance. */
+    constructor($$source: Partial<GroupTreeNode> = {}) {
+        if (!("key" in $$source)) {
+            this["key"] = "";
+        }
+        if (!("name" in $$source)) {
+            this["name"] = "";
+        }
+        if (!("parent_group" in $$source)) {
+            this["parent_group"] = "";
+        }
+        if (!("sort_order" in $$source)) {
+            this["sort_order"] = 0;
+        }
+        if (!("folders" in $$source)) {
+            this["folders"] = [];
+        }
+        if (!("children" in $$source)) {
+            this["children"] = [];
+        }
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new GroupTreeNode instance from a string or object.
+     */
+    static createFrom($$source: any = {}): GroupTreeNode {
+        const $$createField4_0 = $$createType2;
+        const $$createField5_0 = $$createType13;
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        if ("folders" in $$parsedSource) {
+            $$parsedSource["folders"] = $$createField4_0($$parsedSource["folders"]);
+        }
+        if ("children" in $$parsedSource) {
+            $$parsedSource["children"] = $$createField5_0($$parsedSource["children"]);
+        }
+        return new GroupTreeNode($$parsedSource as Partial<GroupTreeNode>);
+    }
+}
+
+/**
+ * OffloadResult is the outcome of offloading a single folder.
+ */
+export class OffloadResult {
+    "folder_key": string;
+    "offloaded": boolean;
+
+    /**
+     * Local files that are missing from, or differ on, the remote
+     */
+    "unpushed_files": DiffEntry[];
+    "error": string;
+
+    /** Creates a new OffloadResult instance. */
+    constructor($$source: Partial<OffloadResult> = {}) {
+        if (!("folder_key" in $$source)) {
+            this["folder_key"] = "";
+        }
+        if (!("offloaded" in $$source)) {
+            this["offloaded"] = false;
+        }
+        if (!("unpushed_files" in $$source)) {
+            this["unpushed_files"] = [];
+        }
+        if (!("error" in $$source)) {
+            this["error"] = "";
+        }
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new OffloadResult instance from a string or object.
+     */
+    static createFrom($$source: any = {}): OffloadResult {
+        const $$createField2_0 = $$createType15;
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        if ("unpushed_files" in $$parsedSource) {
+            $$parsedSource["unpushed_files"] = $$createField2_0($$parsedSource["unpushed_files"]);
+        }
+        return new OffloadResult($$parsedSource as Partial<OffloadResult>);
+    }
+}
+
+/**
+ * PendingConfigSync is a project whose sync.json was saved locally but not uploaded to the remote
+ * yet. The upload always sends the current local sync.json, so any number of queued changes are
+ * pushed together. If the remote sync.json is no longer the version the queued changes were based
+ * on, the upload is held back as a conflict instead, so neither side's edits are overwritten.
+ */
+export class PendingConfigSync {
+    "project": string;
+
+    /**
+     * RFC3339; when the first failed upload was queued
+     */
+    "queued_at": string;
+
+    /**
+     * Failed upload attempts so far
+     */
+    "attempts": number;
+
+    /**
+     * RFC3339
+     */
+    "last_attempt_at": string;
+
+    /**
+     * RFC3339
+     */
+    "next_attempt_at": string;
+    "last_error": string;
+
+    /**
+     * The remote sync.json this machine last pushed or pulled before the upload was queued; nil if unknown
+     */
+    "base_remote": RemoteFileState | null;
+
+    /**
+     * Retries are paused until the conflict is resolved
+     */
+    "conflict": boolean;
+
+    /**
+     * RFC3339; the remote sync.json's mod time when the conflict was found
+     */
+    "remote_mod_time": string;
+
+    /** Creates a new PendingConfigSync instance. */
+    constructor($$source: Partial<PendingConfigSync> = {}) {
+        if (!("project" in $$source)) {
+            this["project"] = "";
+        }
+        if (!("queued_at" in $$source)) {
+            this["queued_at"] = "";
+        }
+        if (!("attempts" in $$source)) {
+            this["attempts"] = 0;
+        }
+        if (!("last_attempt_at" in $$source)) {
+            this["last_attempt_at"] = "";
+        }
+        if (!("next_attempt_at" in $$source)) {
+            this["next_attempt_at"] = "";
+        }
+        if (!("last_error" in $$source)) {
+            this["last_error"] = "";
+        }
+        if (!("base_remote" in $$source)) {
+            this["base_remote"] = null;
+        }
+        if (!("conflict" in $$source)) {
+            this["conflict"] = false;
+        }
+        if (!("remote_mod_time" in $$source)) {
+            this["remote_mod_time"] = "";
+        }
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new PendingConfigSync instance from a string or object.
+     */
+    static createFrom($$source: any = {}): PendingConfigSync {
+        const $$createField6_0 = $$createType17;
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        if ("base_remote" in $$parsedSource) {
+            $$parsedSource["base_remote"] = $$createField6_0($$parsedSource["base_remote"]);
+        }
+        return new PendingConfigSync($$parsedSource as Partial<PendingConfigSync>);
+    }
+}
+
+export class ProjectConfig {
+    "allow_global_sync": boolean;
+    "folders": { [_ in string]?: FolderConfig };
+    "groups": { [_ in string]?: GroupConfig };
+
+    /**
+     * Folders deleted from the remote that can still be restored
+     */
+    "trash"?: TrashEntry[];
+
+    /**
+     * Saved, named folder selections
+     */
+    "selections"?: { [_ in string]?: SelectionConfig };
+
+    /** Creates a new ProjectConfig instance. */
+    constructor($$source: Partial<ProjectConfig> = {}) {
+        if (!("allow_global_sync" in $$source)) {
+            this["allow_global_sync"] = false;
+        }
+        if (!("folders" in $$source)) {
+            this["folders"] = {};
+        }
+        if (!("groups" in $$source)) {
+            this["groups"] = {};
+        }
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new ProjectConfig instance from a string or object.
+     */
+    static createFrom($$source: any = {}): ProjectConfig {
+        const $$createField1_0 = $$createType18;
+        const $$createField2_0 = $$createType8;
+        const $$createField3_0 = $$createType20;
+        const $$createField4_0 = $$createType21;
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        if ("folders" in $$parsedSource) {
+            $$parsedSource["folders"] = $$createField1_0($$parsedSource["folders"]);
+        }
+        if ("groups" in $$parsedSource) {
+            $$parsedSource["groups"] = $$createField2_0($$parsedSource["groups"]);
+        }
+        if ("trash" in $$parsedSource) {
+            $$parsedSource["trash"] = $$createField3_0($$parsedSource["trash"]);
+        }
+        if ("selections" in $$parsedSource) {
+            $$parsedSource["selections"] = $$createField4_0($$parsedSource["selections"]);
+        }
+        return new ProjectConfig($$parsedSource as Partial<ProjectConfig>);
+    }
+}
+
+/**
+ * ProjectDrift is the result of comparing the whole remote bucket against the registered folders.
+ */
+export class ProjectDrift {
+    /**
+     * Shallowest remote directories that no registered folder covers
+     */
+    "unregistered_remote_folders": string[];
+
+    /**
+     * Loose files outside any registered folder or unregistered directory
+     */
+    "unmapped_remote_files": string[];
+
+    /**
+     * Registered folders with nothing on the remote
+     */
+    "missing_remote_folders": string[];
+
+    /**
+     * Registered folders on the remote that aren't downloaded locally
+     */
+    "remote_only_folders": RemoteFolderSummary[];
+
+    /**
+     * Registered folders whose paths are invalid, so their local copy wasn't checked
+     */
+    "invalid_folders": ProjectValidationIssue[];
+
+    /** Creates a new ProjectDrift instance. */
+    constructor($$source: Partial<ProjectDrift> = {}) {
+        if (!("unregistered_remote_folders" in $$source)) {
+            this["unregistered_remote_folders"] = [];
+        }
+        if (!("unmapped_remote_files" in $$source)) {
+            this["unmapped_remote_files"] = [];
+        }
+        if (!("missing_remote_folders" in $$source)) {
+            this["missing_remote_folders"] = [];
+        }
+        if (!("remote_only_folders" in $$source)) {
+            this["remote_only_folders"] = [];
+        }
+        if (!("invalid_folders" in $$source)) {
+            this["invalid_folders"] = [];
+        }
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new ProjectDrift instance from a string or object.
+     */
+    static createFrom($$source: any = {}): ProjectDrift {
+        const $$createField0_0 = $$createType2;
+        const $$createField1_0 = $$createType2;
+        const $$createField2_0 = $$createType2;
+        const $$createField3_0 = $$createType23;
+        const $$createField4_0 = $$createType25;
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        if ("unregistered_remote_folders" in $$parsedSource) {
+            $$parsedSource["unregistered_remote_folders"] = $$createField0_0($$parsedSource["unregistered_remote_folders"]);
+        }
+        if ("unmapped_remote_files" in $$parsedSource) {
+            $$parsedSource["unmapped_remote_files"] = $$createField1_0($$parsedSource["unmapped_remote_files"]);
+        }
+        if ("missing_remote_folders" in $$parsedSource) {
+            $$parsedSource["missing_remote_folders"] = $$createField2_0($$parsedSource["missing_remote_folders"]);
+        }
+        if ("remote_only_folders" in $$parsedSource) {
+            $$parsedSource["remote_only_folders"] = $$createField3_0($$parsedSource["remote_only_folders"]);
+        }
+        if ("invalid_folders" in $$parsedSource) {
+            $$parsedSource["invalid_folders"] = $$createField4_0($$parsedSource["invalid_folders"]);
+        }
+        return new ProjectDrift($$parsedSource as Partial<ProjectDrift>);
+    }
+}
+
+/**
+ * ProjectSettings are the user-editable, non-secret settings of a project's remote.
+ */
+export class ProjectSettings {
+    "remote_name": string;
+    "bucket_name": string;
+    "type": string;
+    "local_path": string;
+    "full_backup_path": string;
+
+    /** Creates a new ProjectSettings instance. */
+    constructor($$source: Partial<ProjectSettings> = {}) {
+        if (!("remote_name" in $$source)) {
+            this["remote_name"] = "";
+        }
+        if (!("bucket_name" in $$source)) {
+            this["bucket_name"] = "";
+        }
+        if (!("type" in $$source)) {
+            this["type"] = "";
+        }
+        if (!("local_path" in $$source)) {
+            this["local_path"] = "";
+        }
+        if (!("full_backup_path" in $$source)) {
+            this["full_backup_path"] = "";
+        }
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new ProjectSettings instance from a string or object.
+     */
+    static createFrom($$source: any = {}): ProjectSettings {
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        return new ProjectSettings($$parsedSource as Partial<ProjectSettings>);
+    }
+}
+
+/**
+ * ProjectSummary describes one configured project for the frontend. It never carries credentials.
+ */
+export class ProjectSummary {
+    "name": string;
+    "remote_name": string;
+    "bucket_name": string;
+    "type": string;
+    "local_path": string;
+    "full_backup_path": string;
+    "encrypted": boolean;
+
+    /**
+     * nil when the project uses the global limit
+     */
+    "bandwidth_limit": BandwidthLimit | null;
+    "transfer_profile": TransferProfile;
+
+    /** Creates a new ProjectSummary instance. */
+    constructor($$source: Partial<ProjectSummary> = {}) {
+        if (!("name" in $$source)) {
+            this["name"] = "";
+        }
+        if (!("remote_name" in $$source)) {
+            this["remote_name"] = "";
+        }
+        if (!("bucket_name" in $$source)) {
+            this["bucket_name"] = "";
+        }
+        if (!("type" in $$source)) {
+            this["type"] = "";
+        }
+        if (!("local_path" in $$source)) {
             this["local_path"] = "";
         }
         if (!("full_backup_path" in $$source)) {
             this["full_backup_path"] = "";
         }
+        if (!("encrypted" in $$source)) {
+            this["encrypted"] = false;
+        }
+        if (!("bandwidth_limit" in $$source)) {
+            this["bandwidth_limit"] = null;
+        }
+        if (!("transfer_profile" in $$source)) {
+            this["transfer_profile"] = (new TransferProfile());
+        }
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new ProjectSummary instance from a string or object.
+     */
+    static createFrom($$source: any = {}): ProjectSummary {
+        const $$createField7_0 = $$createType26;
+        const $$createField8_0 = $$createType27;
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        if ("bandwidth_limit" in $$parsedSource) {
+            $$parsedSource["bandwidth_limit"] = $$createField7_0($$parsedSource["bandwidth_limit"]);
+        }
+        if ("transfer_profile" in $$parsedSource) {
+            $$parsedSource["transfer_profile"] = $$createField8_0($$parsedSource["transfer_profile"]);
+        }
+        return new ProjectSummary($$parsedSource as Partial<ProjectSummary>);
+    }
+}
+
+/**
+ * ProjectValidationIssue is a single problem found in the project configuration.
+ */
+export class ProjectValidationIssue {
+    /**
+     * Keys of the folders involved
+     */
+    "folders": string[];
+    "message": string;
+
+    /** Creates a new ProjectValidationIssue instance. */
+    constructor($$source: Partial<ProjectValidationIssue> = {}) {
+        if (!("folders" in $$source)) {
+            this["folders"] = [];
+        }
+        if (!("message" in $$source)) {
+            this["message"] = "";
+        }
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new ProjectValidationIssue instance from a string or object.
+     */
+    static createFrom($$source: any = {}): ProjectValidationIssue {
+        const $$createField0_0 = $$createType2;
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        if ("folders" in $$parsedSource) {
+            $$parsedSource["folders"] = $$createField0_0($$parsedSource["folders"]);
+        }
+        return new ProjectValidationIssue($$parsedSource as Partial<ProjectValidationIssue>);
+    }
+}
+
+/**
+ * ProjectValidationReport lists every problem found in the project configuration.
+ */
+export class ProjectValidationReport {
+    "valid": boolean;
+    "issues": ProjectValidationIssue[];
+
+    /** Creates a new ProjectValidationReport instance. */
+    constructor($$source: Partial<ProjectValidationReport> = {}) {
+        if (!("valid" in $$source)) {
+            this["valid"] = false;
+        }
+        if (!("issues" in $$source)) {
+            this["issues"] = [];
+        }
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new ProjectValidationReport instance from a string or object.
+     */
+    static createFrom($$source: any = {}): ProjectValidationReport {
+        const $$createField1_0 = $$createType25;
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        if ("issues" in $$parsedSource) {
+            $$parsedSource["issues"] = $$createField1_0($$parsedSource["issues"]);
+        }
+        return new ProjectValidationReport($$parsedSource as Partial<ProjectValidationReport>);
+    }
+}
+
+export enum RcloneAction {
+    /**
+     * The Go zero value for the underlying type of the enum.
+     */
+    $zero = "",
+
+    SYNC_PUSH = "SYNC_PUSH",
+    SYNC_PULL = "SYNC_PULL",
+    COPY_PULL = "COPY_PULL",
+};
+
+export class RcloneActionOutput {
+    "target_folder": string;
+    "command_output": string;
+    "command_error": string;
+
+    /**
+     * One of the ErrorKind constants; empty when there is no error
+     */
+    "error_kind": string;
+
+    /**
+     * Bytes needed, when refused for lack of disk space
+     */
+    "required_bytes": number;
+
+    /**
+     * Bytes free on the volume, when refused for lack of disk space
+     */
+    "available_bytes": number;
+
+    /** Creates a new RcloneActionOutput instance. */
+    constructor($$source: Partial<RcloneActionOutput> = {}) {
+        if (!("target_folder" in $$source)) {
+            this["target_folder"] = "";
+        }
+        if (!("command_output" in $$source)) {
+            this["command_output"] = "";
+        }
+        if (!("command_error" in $$source)) {
+            this["command_error"] = "";
+        }
+        if (!("error_kind" in $$source)) {
+            this["error_kind"] = "";
+        }
+        if (!("required_bytes" in $$source)) {
+            this["required_bytes"] = 0;
+        }
+        if (!("available_bytes" in $$source)) {
+            this["available_bytes"] = 0;
+        }
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new RcloneActionOutput instance from a string or object.
+     */
+    static createFrom($$source: any = {}): RcloneActionOutput {
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        return new RcloneActionOutput($$parsedSource as Partial<RcloneActionOutput>);
+    }
+}
+
+/**
+ * RemoteFileState identifies one version of a file: its modification time, its size, and the
+ * hashes its remote supports. Encrypted remotes have no hashes.
+ */
+export class RemoteFileState {
+    /**
+     * RFC3339 with the remote's precision
+     */
+    "mod_time": string;
+    "size": number;
+
+    /**
+     * Keyed by hash type, such as "sha1"
+     */
+    "hashes": { [_ in string]?: string };
+
+    /** Creates a new RemoteFileState instance. */
+    constructor($$source: Partial<RemoteFileState> = {}) {
+        if (!("mod_time" in $$source)) {
+            this["mod_time"] = "";
+        }
+        if (!("size" in $$source)) {
+            this["size"] = 0;
+        }
+        if (!("hashes" in $$source)) {
+            this["hashes"] = {};
+        }
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new RemoteFileState instance from a string or object.
+     */
+    static createFrom($$source: any = {}): RemoteFileState {
+        const $$createField2_0 = $$createType28;
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        if ("hashes" in $$parsedSource) {
+            $$parsedSource["hashes"] = $$createField2_0($$parsedSource["hashes"]);
+        }
+        return new RemoteFileState($$parsedSource as Partial<RemoteFileState>);
+    }
+}
+
+/**
+ * RemoteFolderSummary describes what a registered folder holds on the remote.
+ */
+export class RemoteFolderSummary {
+    "folder_key": string;
+    "file_count": number;
+    "size": string;
+
+    /**
+     * RFC3339, empty if the folder has no files
+     */
+    "latest_mod_time": string;
+
+    /** Creates a new RemoteFolderSummary instance. */
+    constructor($$source: Partial<RemoteFolderSummary> = {}) {
+        if (!("folder_key" in $$source)) {
+            this["folder_key"] = "";
+        }
+        if (!("file_count" in $$source)) {
+            this["file_count"] = 0;
+        }
+        if (!("size" in $$source)) {
+            this["size"] = "";
+        }
+        if (!("latest_mod_time" in $$source)) {
+            this["latest_mod_time"] = "";
+        }
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new RemoteFolderSummary instance from a string or object.
+     */
+    static createFrom($$source: any = {}): RemoteFolderSummary {
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        return new RemoteFolderSummary($$parsedSource as Partial<RemoteFolderSummary>);
+    }
+}
+
+/**
+ * SelectionConfig is a saved, named set of folders, such as "Episode 3 lighting" or "All audio".
+ * A folder belongs to the selection if it matches any tag query, is in any of the groups or their
+ * descendant groups, or is listed explicitly.
+ */
+export class SelectionConfig {
+    /**
+     * Display name
+     */
+    "name": string;
+
+    /**
+     * Each query is a set of tags joined with "+"; a folder must have all of them
+     */
+    "tag_queries": string[];
+    "groups": string[];
+    "folders": string[];
+
+    /** Creates a new SelectionConfig instance. */
+    constructor($$source: Partial<SelectionConfig> = {}) {
+        if (!("name" in $$source)) {
+            this["name"] = "";
+        }
+        if (!("tag_queries" in $$source)) {
+            this["tag_queries"] = [];
+        }
+        if (!("groups" in $$source)) {
+            this["groups"] = [];
+        }
+        if (!("folders" in $$source)) {
+            this["folders"] = [];
+        }
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new SelectionConfig instance from a string or object.
+     */
+    static createFrom($$source: any = {}): SelectionConfig {
+        const $$createField1_0 = $$createType2;
+        const $$createField2_0 = $$createType2;
+        const $$createField3_0 = $$createType2;
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        if ("tag_queries" in $$parsedSource) {
+            $$parsedSource["tag_queries"] = $$createField1_0($$parsedSource["tag_queries"]);
+        }
+        if ("groups" in $$parsedSource) {
+            $$parsedSource["groups"] = $$createField2_0($$parsedSource["groups"]);
+        }
+        if ("folders" in $$parsedSource) {
+            $$parsedSource["folders"] = $$createField3_0($$parsedSource["folders"]);
+        }
+        return new SelectionConfig($$parsedSource as Partial<SelectionConfig>);
+    }
+}
+
+/**
+ * TransferProfile tunes how a project's files are transferred. Zero values keep rclone's defaults.
+ * Large files, like multi-GB EXR and cache files, upload much faster with larger chunks and more
+ * streams, at the cost of memory.
+ */
+export class TransferProfile {
+    /**
+     * Files transferred in parallel (rclone default 4)
+     */
+    "transfers": number;
+
+    /**
+     * Files compared in parallel (rclone default 8)
+     */
+    "checkers": number;
+
+    /**
+     * Streams per large file (rclone default 4)
+     */
+    "multi_thread_streams": number;
+
+    /**
+     * Size of each part of a large upload, e.g. "96M"
+     */
+    "upload_chunk_size": string;
+
+    /**
+     * Memory buffer per transfer, e.g. "64M" (rclone default 16M)
+     */
+    "buffer_size": string;
+
+    /**
+     * List the remote in one recursive call; fewer API calls, more memory
+     */
+    "fast_list": boolean;
+
+    /** Creates a new TransferProfile instance. */
+    constructor($$source: Partial<TransferProfile> = {}) {
+        if (!("transfers" in $$source)) {
+            this["transfers"] = 0;
+        }
+        if (!("checkers" in $$source)) {
+            this["checkers"] = 0;
+        }
+        if (!("multi_thread_streams" in $$source)) {
+            this["multi_thread_streams"] = 0;
+        }
+        if (!("upload_chunk_size" in $$source)) {
+            this["upload_chunk_size"] = "";
+        }
+        if (!("buffer_size" in $$source)) {
+            this["buffer_size"] = "";
+        }
+        if (!("fast_list" in $$source)) {
+            this["fast_list"] = false;
+        }
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new TransferProfile instance from a string or object.
+     */
+    static createFrom($$source: any = {}): TransferProfile {
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        return new TransferProfile($$parsedSource as Partial<TransferProfile>);
+    }
+}
+
+/**
+ * TrashEntry records a folder that was deleted from the remote. It keeps the folder's registration
+ * so the folder can be restored exactly as it was.
+ */
+export class TrashEntry {
+    "folder_key": string;
+    "folder_config": FolderConfig;
+
+    /**
+     * Relative to the remote project root
+     */
+    "trash_path": string;
+
+    /**
+     * RFC3339
+     */
+    "deleted_at": string;
+
+    /**
+     * Host the deletion was made from
+     */
+    "deleted_by": string;
+
+    /** Creates a new TrashEntry instance. */
+    constructor($$source: Partial<TrashEntry> = {}) {
+        if (!("folder_key" in $$source)) {
+            this["folder_key"] = "";
+        }
+        if (!("folder_config" in $$source)) {
+            this["folder_config"] = (new FolderConfig());
+        }
+        if (!("trash_path" in $$source)) {
+            this["trash_path"] = "";
+        }
+        if (!("deleted_at" in $$source)) {
+            this["deleted_at"] = "";
+        }
+        if (!("deleted_by" in $$source)) {
+            this["deleted_by"] = "";
+        }
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new TrashEntry instance from a string or object.
+     */
+    static createFrom($$source: any = {}): TrashEntry {
+        const $$createField1_0 = $$createType3;
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        if ("folder_config" in $$parsedSource) {
+            $$parsedSource["folder_config"] = $$createField1_0($$parsedSource["folder_config"]);
+        }
+        return new TrashEntry($$parsedSource as Partial<TrashEntry>);
+    }
+}
+
+/**
+ * UntrackedFolder is a local directory that no registered folder covers, so it is never backed up.
+ */
+export class UntrackedFolder {
+    /**
+     * Relative to the project root
+     */
+    "local_path": string;
+    "file_count": number;
+    "size": string;
+
+    /** Creates a new UntrackedFolder instance. */
+    constructor($$source: Partial<UntrackedFolder> = {}) {
+        if (!("local_path" in $$source)) {
+            this["local_path"] = "";
+        }
+        if (!("file_count" in $$source)) {
+            this["file_count"] = 0;
+        }
+        if (!("size" in $$source)) {
+            this["size"] = "";
+        }
 
         Object.assign(this, $$source);
     }
 
     /**
-     * Creates a new RemoteConfig instance from a string or object.
+     * Creates a new UntrackedFolder instance from a string or object.
      */
-    static createFrom($$source: any = {}): RemoteConfig {
+    static createFrom($$source: any = {}): UntrackedFolder {
         let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
-        return new RemoteConfig($$parsedSource as Partial<RemoteConfig>);
+        return new UntrackedFolder($$parsedSource as Partial<UntrackedFolder>);
     }
 }
 
 // Private type creation functions
-const $$createType0 = RemoteConfig.createFrom;
-const $$createType1 = $Create.Map($Create.Any, $$createType0);
-const $$createType2 = FolderConfig.createFrom;
-const $$createType3 = $Create.Map($Create.Any, $$createType2);
+const $$createType0 = BandwidthScheduleEntry.createFrom;
+const $$createType1 = $Create.Array($$createType0);
+const $$createType2 = $Create.Array($Create.Any);
+const $$createType3 = FolderConfig.createFrom;
 const $$createType4 = GroupConfig.createFrom;
-const $$createType5 = $Create.Map($Create.Any, $$createType4);
+const $$createType5 = SelectionConfig.createFrom;
+const $$createType6 = FolderRegistration.createFrom;
+const $$createType7 = $Create.Array($$createType6);
+const $$createType8 = $Create.Map($Create.Any, $$createType4);
+const $$createType9 = ProjectSummary.createFrom;
+const $$createType10 = $Create.Array($$createType9);
+const $$createType11 = BandwidthLimit.createFrom;
+const $$createType12 = GroupTreeNode.createFrom;
+const $$createType13 = $Create.Array($$createType12);
+const $$createType14 = DiffEntry.createFrom;
+const $$createType15 = $Create.Array($$createType14);
+const $$createType16 = RemoteFileState.createFrom;
+const $$createType17 = $Create.Nullable($$createType16);
+const $$createType18 = $Create.Map($Create.Any, $$createType3);
+const $$createType19 = TrashEntry.createFrom;
+const $$createType20 = $Create.Array($$createType19);
+const $$createType21 = $Create.Map($Create.Any, $$createType5);
+const $$createType22 = RemoteFolderSummary.createFrom;
+const $$createType23 = $Create.Array($$createType22);
+const $$createType24 = ProjectValidationIssue.createFrom;
+const $$createType25 = $Create.Array($$createType24);
+const $$createType26 = $Create.Nullable($$createType11);
+const $$createType27 = TransferProfile.createFrom;
+const $$createType28 = $Create.Map($Create.Any, $Create.Any);
@@ -21,9 +21,32 @@ export function DetectChangedFolders(localFolders: string[]): $CancellablePromis
 /**
  * DetectChangedFoldersAsync runs change detection in parallel, emitting per-folder events.
  * Emits "detect-folder-complete" for each folder and "detect-complete" when all done.
+ * The folders of the named selection, if one is given, are checked as well.
  */
-export function DetectChangedFoldersAsync(taskID: string, localFolders: string[]): $CancellablePromise<void> {
-    return $Call.ByID(808215963, taskID, localFolders);
+export function DetectChangedFoldersAsync(taskID: string, localFolders: string[], selectionName: string): $CancellablePromise<void> {
+    return $Call.ByID(808215963, taskID, localFolders, selectionName);
+}
+
+/**
+ * DetectGroupChanges checks every checked-out folder in the group and its descendant groups for
+ * changes, and returns a summary for the group and each descendant group with the counts rolled up
+ * the hierarchy. The summaries are sorted by group key.
+ */
+export function DetectGroupChanges(groupKey: string): $CancellablePromise<$models.GroupChangeSummary[]> {
+    return $Call.ByID(4222711972, groupKey).then(($result: any) => {
+        return $$createType2($result);
+    });
+}
+
+/**
+ * DetectProjectDrift lists the whole remote project and maps every path to its registered folder.
+ * It reports remote directories nobody has registered yet, registered folders that are missing on
+ * the remote, and what the remote holds for registered folders that aren't downloaded locally.
+ */
+export function DetectProjectDrift(): $CancellablePromise<$models.ProjectDrift> {
+    return $Call.ByID(369150732).then(($result: any) => {
+        return $$createType3($result);
+    });
 }
 
 /**
@@ -31,7 +54,7 @@ export function DetectChangedFoldersAsync(taskID: string, localFolders: string[]
  */
 export function ExecuteFullBackup(dry: boolean): $CancellablePromise<$models.RcloneActionOutput[]> {
     return $Call.ByID(1373287177, dry).then(($result: any) => {
-        return $$createType2($result);
+        return $$createType5($result);
     });
 }
 
@@ -43,12 +66,47 @@ export function ExecuteFullBackupAsync(taskID: string, dry: boolean): $Cancellab
     return $Call.ByID(2263277875, taskID, dry);
 }
 
+/**
+ * ExecuteGroupAction runs the action on every folder in the group and its descendant groups.
+ */
+export function ExecuteGroupAction(groupKey: string, action: $models.RcloneAction, dry: boolean): $CancellablePromise<$models.RcloneActionOutput[]> {
+    return $Call.ByID(499235359, groupKey, action, dry).then(($result: any) => {
+        return $$createType5($result);
+    });
+}
+
+/**
+ * ExecuteGroupActionAsync runs the action on every folder in the group and its descendant groups in
+ * the background, emitting the same events as ExecuteRcloneActionAsync.
+ */
+export function ExecuteGroupActionAsync(taskID: string, groupKey: string, action: $models.RcloneAction, dry: boolean): $CancellablePromise<void> {
+    return $Call.ByID(237161297, taskID, groupKey, action, dry);
+}
+
+/**
+ * ExecuteProjectPull pulls every registered folder, and optionally every unregistered remote
+ * directory, down to the project's local path. Only allowed when the project enables AllowGlobalSync.
+ */
+export function ExecuteProjectPull(includeUnregistered: boolean, dry: boolean): $CancellablePromise<$models.RcloneActionOutput[]> {
+    return $Call.ByID(3828972924, includeUnregistered, dry).then(($result: any) => {
+        return $$createType5($result);
+    });
+}
+
+/**
+ * ExecuteProjectPullAsync runs the whole-project pull in the background, emitting a
+ * "task-folder-complete" event per folder and a "task-complete" event when all are done.
+ */
+export function ExecuteProjectPullAsync(taskID: string, includeUnregistered: boolean, dry: boolean): $CancellablePromise<void> {
+    return $Call.ByID(3303045856, taskID, includeUnregistered, dry);
+}
+
 /**
  * Error handling is done per request, and gracefully returned to the user for evaluation in the frontend.
  */
 export function ExecuteRcloneAction(targetFolders: string[], action: $models.RcloneAction, dry: boolean): $CancellablePromise<$models.RcloneActionOutput[]> {
     return $Call.ByID(1430199943, targetFolders, action, dry).then(($result: any) => {
-        return $$createType2($result);
+        return $$createType5($result);
     });
 }
 
@@ -56,13 +114,31 @@ export function ExecuteRcloneAction(targetFolders: string[], action: $models.Rcl
  * ExecuteRcloneActionAsync runs the rclone action for each folder in parallel,
  * emitting a "task-folder-complete" event as each folder finishes.
  * When all folders are done, emits a "task-complete" event.
+ * The folders of the named selection, if one is given, are added to the target folders.
  * Returns immediately; the taskID correlates events to the original request.
  */
-export function ExecuteRcloneActionAsync(taskID: string, targetFolders: string[], action: $models.RcloneAction, dry: boolean): $CancellablePromise<void> {
-    return $Call.ByID(1463246281, taskID, targetFolders, action, dry);
+export function ExecuteRcloneActionAsync(taskID: string, targetFolders: string[], selectionName: string, action: $models.RcloneAction, dry: boolean): $CancellablePromise<void> {
+    return $Call.ByID(1463246281, taskID, targetFolders, selectionName, action, dry);
+}
+
+/**
+ * GetFolderStatuses returns the status of every registered folder in one call. Local sizes are
+ * measured on every call. Remote sizes come from a cache, which is refreshed with a single listing
+ * of the whole remote when refreshRemote is set. The dirty state is the outcome of the last change
+ * detection, push, or pull on this machine.
+ */
+export function GetFolderStatuses(refreshRemote: boolean): $CancellablePromise<$models.FolderStatus[]> {
+    return $Call.ByID(1091263883, refreshRemote).then(($result: any) => {
+        return $$createType7($result);
+    });
 }
 
 // Private type creation functions
 const $$createType0 = $Create.Array($Create.Any);
-const $$createType1 = $models.RcloneActionOutput.createFrom;
+const $$createType1 = $models.GroupChangeSummary.createFrom;
 const $$createType2 = $Create.Array($$createType1);
+const $$createType3 = $models.ProjectDrift.createFrom;
+const $$createType4 = $models.RcloneActionOutput.createFrom;
+const $$createType5 = $Create.Array($$createType4);
+const $$createType6 = $models.FolderStatus.createFrom;
+const $$createType7 = $Create.Array($$createType6);
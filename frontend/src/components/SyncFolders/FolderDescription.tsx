@@ -20,7 +20,7 @@ const FolderDescription: React.FC<FolderDescriptionProps> = ({ folderConfig }) =
         if (globalConfig === undefined || selectedProject === undefined) {
             return { localRoot: undefined, remoteRoot: undefined };
         }
-        const remote = globalConfig.remotes[selectedProject];
+        const remote = globalConfig.projects.find((project) => project.name === selectedProject);
         if (!remote) return { localRoot: undefined, remoteRoot: undefined };
         return { localRoot: remote.local_path, remoteRoot: remote.bucket_name };
     }, [globalConfig, selectedProject])
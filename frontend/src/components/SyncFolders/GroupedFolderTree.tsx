@@ -110,9 +110,14 @@ function buildGroupTree(
     };
     sortGroups(rootNodes);
 
-    // Sort folders alphabetically within each group
+    // Sort folders by sort_order, then alphabetically within each group
     Object.values(groupNodes).forEach((node) => {
-        node.folders.sort((a, b) => a.key.localeCompare(b.key));
+        node.folders.sort((a, b) => {
+            if (a.config.sort_order !== b.config.sort_order) {
+                return a.config.sort_order - b.config.sort_order;
+            }
+            return a.key.localeCompare(b.key);
+        });
     });
 
     return rootNodes;
@@ -93,6 +93,12 @@ function getSortedGroupsHierarchical(groups: Record<string, GroupConfig>): strin
     return result;
 }
 
+// Parse a position field; empty or invalid input gives -1, which the backend reads as "last" or "keep"
+function parsePosition(value: string): number {
+    const position = parseInt(value, 10);
+    return Number.isNaN(position) || position < 0 ? -1 : position;
+}
+
 const ManageGroupsDialog: React.FC<ManageGroupsDialogProps> = ({ isOpen, setIsOpen }) => {
     const { projectConfig, setProjectConfig } = useProjectConfig();
 
@@ -135,7 +141,7 @@ const ManageGroupsDialog: React.FC<ManageGroupsDialogProps> = ({ isOpen, setIsOp
     // Form state
     const [newGroupName, setNewGroupName] = useState("");
     const [newGroupParent, setNewGroupParent] = useState("");
-    const [newGroupSortOrder, setNewGroupSortOrder] = useState(0);
+    const [newGroupPosition, setNewGroupPosition] = useState(-1); // -1 adds the group last, or keeps its position when editing
     const [isLoading, setIsLoading] = useState(false);
     const [error, setError] = useState<string | null>(null);
 
@@ -147,7 +153,7 @@ const ManageGroupsDialog: React.FC<ManageGroupsDialogProps> = ({ isOpen, setIsOp
     const handleOpenAdd = () => {
         setNewGroupName("");
         setNewGroupParent("");
-        setNewGroupSortOrder(0);
+        setNewGroupPosition(-1);
         setError(null);
         setIsAddDialogOpen(true);
     };
@@ -158,7 +164,7 @@ const ManageGroupsDialog: React.FC<ManageGroupsDialogProps> = ({ isOpen, setIsOp
             setEditingGroupKey(groupKey);
             setNewGroupName(group.name);
             setNewGroupParent(group.parent_group || "");
-            setNewGroupSortOrder(group.sort_order || 0);
+            setNewGroupPosition(-1);
             setError(null);
             setIsEditDialogOpen(true);
         }
@@ -181,15 +187,14 @@ const ManageGroupsDialog: React.FC<ManageGroupsDialogProps> = ({ isOpen, setIsOp
             const groupConfig = new GroupConfig({
                 name: newGroupName.trim(),
                 parent_group: newGroupParent || "",
-                sort_order: newGroupSortOrder,
             });
 
-            const updatedConfig: ProjectConfig = await FolderService.CreateGroup(groupKey, groupConfig);
+            const updatedConfig: ProjectConfig = await FolderService.CreateGroup(groupKey, groupConfig, newGroupPosition);
             setProjectConfig(updatedConfig);
             setIsAddDialogOpen(false);
             setNewGroupName("");
             setNewGroupParent("");
-            setNewGroupSortOrder(0);
+            setNewGroupPosition(-1);
         } catch (e: any) {
             setError(e.message || "Failed to create group");
         } finally {
@@ -207,16 +212,15 @@ const ManageGroupsDialog: React.FC<ManageGroupsDialogProps> = ({ isOpen, setIsOp
             const groupConfig = new GroupConfig({
                 name: newGroupName.trim(),
                 parent_group: newGroupParent || "",
-                sort_order: newGroupSortOrder,
             });
 
-            const updatedConfig: ProjectConfig = await FolderService.UpdateGroup(editingGroupKey, groupConfig);
+            const updatedConfig: ProjectConfig = await FolderService.UpdateGroup(editingGroupKey, groupConfig, newGroupPosition);
             setProjectConfig(updatedConfig);
             setIsEditDialogOpen(false);
             setEditingGroupKey(null);
             setNewGroupName("");
             setNewGroupParent("");
-            setNewGroupSortOrder(0);
+            setNewGroupPosition(-1);
         } catch (e: any) {
             setError(e.message || "Failed to update group");
         } finally {
@@ -229,7 +233,7 @@ const ManageGroupsDialog: React.FC<ManageGroupsDialogProps> = ({ isOpen, setIsOp
             setIsLoading(true);
             setError(null);
 
-            const updatedConfig: ProjectConfig = await FolderService.DeleteGroup(groupKey);
+            const updatedConfig: ProjectConfig = await FolderService.DeleteGroup(groupKey, "", "");
             setProjectConfig(updatedConfig);
         } catch (e: any) {
             setError(e.message || "Failed to delete group");
@@ -382,13 +386,13 @@ const ManageGroupsDialog: React.FC<ManageGroupsDialogProps> = ({ isOpen, setIsOp
                         </Select>
                     </FormControl>
                     <TextField
-                        label="Sort Order"
+                        label="Position"
                         type="number"
-                        value={newGroupSortOrder}
-                        onChange={(e) => setNewGroupSortOrder(parseInt(e.target.value) || 0)}
+                        value={newGroupPosition < 0 ? "" : newGroupPosition}
+                        onChange={(e) => setNewGroupPosition(parsePosition(e.target.value))}
                         fullWidth
                         margin="normal"
-                        helperText="Lower numbers appear first. Groups with same order are sorted alphabetically."
+                        helperText="Position among the sibling groups, starting at 0. Leave empty to add it last."
                     />
                 </DialogContent>
                 <DialogActions>
@@ -442,13 +446,13 @@ const ManageGroupsDialog: React.FC<ManageGroupsDialogProps> = ({ isOpen, setIsOp
                         </Select>
                     </FormControl>
                     <TextField
-                        label="Sort Order"
+                        label="Position"
                         type="number"
-                        value={newGroupSortOrder}
-                        onChange={(e) => setNewGroupSortOrder(parseInt(e.target.value) || 0)}
+                        value={newGroupPosition < 0 ? "" : newGroupPosition}
+                        onChange={(e) => setNewGroupPosition(parsePosition(e.target.value))}
                         fullWidth
                         margin="normal"
-                        helperText="Lower numbers appear first. Groups with same order are sorted alphabetically."
+                        helperText="Position among the sibling groups, starting at 0. Leave empty to keep its current position, or to add it last under a new parent."
                     />
                 </DialogContent>
                 <DialogActions>
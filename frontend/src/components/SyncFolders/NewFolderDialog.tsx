@@ -66,7 +66,7 @@ const NewFolderDialog: React.FC<NewFolderDialogProps> = ({ isOpen, setIsOpen })
         if (globalConfig === undefined || selectedProject === undefined) {
             return undefined;
         }
-        return globalConfig.remotes[selectedProject]?.local_path;
+        return globalConfig.projects.find((project) => project.name === selectedProject)?.local_path;
     }, [globalConfig, selectedProject]);
 
     // Project config state
@@ -196,10 +196,10 @@ const NewFolderDialog: React.FC<NewFolderDialogProps> = ({ isOpen, setIsOpen })
             const groupConfig = new GroupConfig({
                 name: newGroupName.trim(),
                 parent_group: "",
-                sort_order: 0,
             });
 
-            const updatedConfig = await FolderService.CreateGroup(groupKey, groupConfig);
+            // -1 adds it after the existing groups
+            const updatedConfig = await FolderService.CreateGroup(groupKey, groupConfig, -1);
             setProjectConfig(updatedConfig);
 
             // Select the newly created group
@@ -26,6 +26,7 @@ const ProjectDashboard: React.FunctionComponent<ProjectSelectorChildProps> = ({
     // State for local deletion
     const [isDeleteDialogOpen, setIsDeleteDialogOpen] = useState<boolean>(false);
     const [isDeletingLocal, setIsDeletingLocal] = useState<boolean>(false);
+    const [offloadError, setOffloadError] = useState<string | null>(null);
 
     // State for new folder registration
     const [isNewFolderDialogOpen, setIsNewFolderDialogOpen] = useState<boolean>(false)
@@ -58,13 +59,21 @@ const ProjectDashboard: React.FunctionComponent<ProjectSelectorChildProps> = ({
         setTargetFolders([]);
     }, [targetFolders, startRcloneAction]);
 
-    // Delete the targeted folders from the local file system.
+    // Offload the targeted folders: they are only deleted locally once the remote is verified to hold every file.
     const handleRemoveLocal = async () => {
         try {
             setIsDeletingLocal(true);
-            await FolderService.DeleteLocalFolders(targetFolders);
+            const results = await FolderService.OffloadFolders(targetFolders);
+            const refused = results.filter((result) => !result.offloaded);
+            if (refused.length > 0) {
+                setOffloadError(refused.map((result) => {
+                    const files = result.unpushed_files.slice(0, 5).map((file) => file.path).join(", ");
+                    return files ? `${result.error} (${files}${result.unpushed_files.length > 5 ? ", ..." : ""})` : result.error;
+                }).join("\n"));
+            }
         } catch (e: any) {
             console.error(e);
+            setOffloadError(`${e}`);
         } finally {
             setIsDeletingLocal(false);
             setIsDeleteDialogOpen(false);
@@ -272,7 +281,7 @@ const ProjectDashboard: React.FunctionComponent<ProjectSelectorChildProps> = ({
                             handleClose={(_event, _reason) => setIsDeleteDialogOpen(false)}
                             handleConfirm={handleRemoveLocal}
                         >
-                            <Typography>All selected folders will be deleted from your local file system.</Typography>
+                            <Typography>All selected folders will be deleted from your local file system. Folders with files that aren't on the remote yet are kept.</Typography>
                         </StandardDialog>
                     </Grid2>
                     <Grid2 size={12} height={"86%"} display={"table"}>
@@ -317,6 +326,22 @@ const ProjectDashboard: React.FunctionComponent<ProjectSelectorChildProps> = ({
                         Failed to download "{downloadError?.folder}": {downloadError?.message}
                     </Alert>
                 </Snackbar>
+
+                {/* Offload refusal snackbar */}
+                <Snackbar
+                    open={offloadError !== null}
+                    onClose={() => setOffloadError(null)}
+                    anchorOrigin={{ vertical: "bottom", horizontal: "center" }}
+                >
+                    <Alert
+                        onClose={() => setOffloadError(null)}
+                        severity="warning"
+                        variant="filled"
+                        sx={{ whiteSpace: "pre-line" }}
+                    >
+                        {offloadError}
+                    </Alert>
+                </Snackbar>
             </Grid2>
 
         ) : (
@@ -23,8 +23,8 @@ const ProjectSelector: React.FC<ProjectSelectorProps> = ({ ProjectSelectorChild
 
     // Get the project options.
     const projectOptions = useMemo(() => {
-        return Object.keys(globalConfig?.remotes ?? {}) || ["None"];
-    }, [globalConfig?.remotes]);
+        return (globalConfig?.projects ?? []).map((project) => project.name);
+    }, [globalConfig?.projects]);
 
     return (
         <Grid2 container spacing={1} height={800}>
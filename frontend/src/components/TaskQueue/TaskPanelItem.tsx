@@ -50,6 +50,36 @@ function getStatusColor(task: Task): "success" | "error" | "warning" | "secondar
     return "primary";
 }
 
+// Plain explanations for the error kinds reported by the backend
+const ERROR_KIND_HINTS: Record<string, string> = {
+    auth: "The remote rejected the credentials. The application key may have expired or been revoked; update it in the project settings.",
+    not_found: "The folder or bucket could not be found. It may have been moved, deleted, or not pushed yet.",
+    rate_limited: "The storage provider is limiting requests. Wait a few minutes and try again.",
+    network: "The remote could not be reached. Check your network or VPN connection and try again.",
+    permission: "Access was denied. Check the application key's permissions and the local folder's permissions.",
+    disk_full: "There is not enough free disk space. Free up space or pull fewer folders at once.",
+    config: "The folder is missing from the project configuration. It may have been renamed or removed; reload the project and try again.",
+};
+
+function formatBytes(bytes: number) {
+    const units = ["B", "KiB", "MiB", "GiB", "TiB"];
+    let value = bytes;
+    let unit = 0;
+    while (value >= 1024 && unit < units.length - 1) {
+        value /= 1024;
+        unit++;
+    }
+    return `${value.toFixed(unit === 0 ? 0 : 1)} ${units[unit]}`;
+}
+
+function formatError(result: TaskFolderResult) {
+    let hint = ERROR_KIND_HINTS[result.errorKind];
+    if (hint && result.requiredBytes > 0) {
+        hint += ` ${formatBytes(result.requiredBytes)} is needed, ${formatBytes(result.availableBytes)} is free.`;
+    }
+    return hint ? `${hint}\n\n${result.commandError}` : result.commandError;
+}
+
 // --- Tab panel ---
 
 interface TabPanelProps {
@@ -203,7 +233,7 @@ const TaskPanelItem: React.FC<TaskPanelItemProps> = ({ task, isExpanded, onToggl
                     {task.folders.map((folder, index) => {
                         const result: TaskFolderResult | undefined = task.results[folder];
                         const hasError = result ? result.commandError.length > 0 : false;
-                        const output = result ? (result.commandError || result.commandOutput || "") : "";
+                        const output = result ? (hasError ? formatError(result) : (result.commandOutput || "")) : "";
                         const diffResult = result && !hasError ? parseDiffResult(result.commandOutput) : null;
 
                         return (
@@ -1,9 +1,9 @@
 import { createContext, useContext, useEffect, useState } from "react";
-import { GlobalConfig } from "../../bindings/github.com/ethanstovall/rclone-selective-sync/backend/models.ts";
+import { GlobalConfigView } from "../../bindings/github.com/ethanstovall/rclone-selective-sync/backend/models.ts";
 import { ConfigService } from "../../bindings/github.com/ethanstovall/rclone-selective-sync/backend";
 
 interface GlobalConfigContextProps {
-    globalConfig: GlobalConfig | undefined;
+    globalConfig: GlobalConfigView | undefined;
     selectedProject: string | undefined;
     isLoadingGlobalConfig: boolean;
     setSelectedProject: (selectProject: string) => void;
@@ -30,15 +30,15 @@ const useGlobalConfig = () => {
 };
 
 const GlobalConfigContextProvider = ({ children }) => {
-    const [globalConfig, setGlobalConfig] = useState<GlobalConfig | undefined>(undefined);
+    const [globalConfig, setGlobalConfig] = useState<GlobalConfigView | undefined>(undefined);
     const [selectedProject, setSelectedProject] = useState<string | undefined>(undefined);
     const [isLoadingGlobalConfig, setIsLoadingGlobalConfig] = useState<boolean>(true);
 
     useEffect(() => {
         setIsLoadingGlobalConfig(true)
-        ConfigService.LoadGlobalConfig().then(([loadedGlobalConfig, selectedProject]: [GlobalConfig, string]) => {
+        ConfigService.LoadGlobalConfig().then((loadedGlobalConfig: GlobalConfigView) => {
             setGlobalConfig(loadedGlobalConfig);
-            setSelectedProject(selectedProject);
+            setSelectedProject(loadedGlobalConfig.selected_project);
         }).catch((err: any) => {
             console.error(err);
         }).finally(() => {
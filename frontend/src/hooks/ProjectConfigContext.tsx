@@ -3,7 +3,7 @@ import { ProjectConfig } from "../../bindings/github.com/ethanstovall/rclone-sel
 import { ConfigService } from "../../bindings/github.com/ethanstovall/rclone-selective-sync/backend";
 import { useGlobalConfig } from "./GlobalConfigContext.tsx";
 import { Events } from "@wailsio/runtime";
-import { Alert, Snackbar } from "@mui/material";
+import { Alert, Button, Snackbar } from "@mui/material";
 
 interface ProjectConfigContextProps {
     projectConfig: ProjectConfig | undefined;
@@ -35,6 +35,7 @@ interface SyncStatusWarning {
     message: string;
     localModTime?: string;
     remoteModTime?: string;
+    conflict?: boolean; // Local and remote sync.json both changed; the user has to keep one
 }
 
 const ProjectConfigContextProvider = ({ children }) => {
@@ -67,6 +68,59 @@ const ProjectConfigContextProvider = ({ children }) => {
         };
     }, []);
 
+    // Listen for config-sync-pending events from the backend
+    useEffect(() => {
+        const unsubscribe = Events.On("config-sync-pending", (event: { data: { pending: { project: string; last_error: string; conflict: boolean }[] } }) => {
+            const pending = event.data.pending.find((entry) => entry.project === selectedProject);
+            // Conflicts are reported by their own event, with a choice of which copy to keep
+            if (pending && !pending.conflict) {
+                setSyncWarning({
+                    open: true,
+                    message: `Project configuration changes are saved locally and will be uploaded when the remote is reachable again (${pending.last_error})`,
+                });
+            }
+        });
+
+        return () => {
+            unsubscribe();
+        };
+    }, [selectedProject]);
+
+    // Listen for config-sync-conflict events from the backend
+    useEffect(() => {
+        const unsubscribe = Events.On("config-sync-conflict", (event: { data: Record<string, string> }) => {
+            const data = event.data;
+            if (data.project === selectedProject) {
+                setSyncWarning({
+                    open: true,
+                    message: data.message,
+                    localModTime: data.localModTime,
+                    remoteModTime: data.remoteModTime,
+                    conflict: true,
+                });
+            }
+        });
+
+        return () => {
+            unsubscribe();
+        };
+    }, [selectedProject]);
+
+    // Listen for untracked-folders events from the backend
+    useEffect(() => {
+        const unsubscribe = Events.On("untracked-folders", (event: { data: { folders: { local_path: string }[] } }) => {
+            const paths = event.data.folders.map((folder) => folder.local_path);
+            setSyncWarning({
+                open: true,
+                message: `${paths.length} local folder(s) aren't registered and won't be backed up: ${paths.join(", ")}`,
+            });
+        });
+
+        return () => {
+            unsubscribe();
+        };
+    }, []);
+
     useEffect(() => {
         const loadProjectConfig = async () => {
             if (!selectedProject) return;
@@ -79,6 +133,7 @@ const ProjectConfigContextProvider = ({ children }) => {
                 setProjectConfig(loadedProjectConfig);
             } catch (error) {
                 console.error(`Error loading project ${selectedProject} config:`, error);
+                setSyncWarning({ open: true, message: `Error loading project ${selectedProject}: ${error}` });
             } finally {
                 setIsLoadingProject(false);
             }
@@ -91,13 +146,33 @@ const ProjectConfigContextProvider = ({ children }) => {
         setSyncWarning({ ...syncWarning, open: false });
     };
 
+    // Resolve a sync.json conflict by uploading the local copy over the remote one
+    const handleKeepLocal = async () => {
+        try {
+            await ConfigService.KeepLocalSyncFile();
+            setSyncWarning({ open: false, message: "" });
+        } catch (error) {
+            setSyncWarning({ open: true, message: `Failed to upload the local project configuration: ${error}`, conflict: true });
+        }
+    };
+
+    // Resolve a sync.json conflict by replacing the local copy with the remote one
+    const handleKeepRemote = async () => {
+        try {
+            setProjectConfig(await ConfigService.RefreshSyncFile());
+            setSyncWarning({ open: false, message: "" });
+        } catch (error) {
+            setSyncWarning({ open: true, message: `Failed to download the remote project configuration: ${error}`, conflict: true });
+        }
+    };
+
     return (
         // The Provider gives access to the context to its children.
         <ProjectConfigContext.Provider value={{ projectConfig, isLoadingProject, setProjectConfig }}>
             {children}
             <Snackbar
                 open={syncWarning.open}
-                autoHideDuration={10000}
+                autoHideDuration={syncWarning.conflict ? null : 10000}
                 onClose={handleCloseSyncWarning}
                 anchorOrigin={{ vertical: "bottom", horizontal: "center" }}
             >
@@ -106,6 +181,12 @@ const ProjectConfigContextProvider = ({ children }) => {
                     severity="warning"
                     variant="filled"
                     sx={{ width: "100%" }}
+                    action={syncWarning.conflict ? (
+                        <>
+                            <Button color="inherit" size="small" onClick={handleKeepLocal}>Keep mine</Button>
+                            <Button color="inherit" size="small" onClick={handleKeepRemote}>Use remote</Button>
+                        </>
+                    ) : undefined}
                 >
                     {syncWarning.message}
                     {syncWarning.localModTime && syncWarning.remoteModTime && (
@@ -10,6 +10,9 @@ export interface TaskFolderResult {
     targetFolder: string;
     commandOutput: string;
     commandError: string;
+    errorKind: string;
+    requiredBytes: number;
+    availableBytes: number;
     completedAt: number;
 }
 
@@ -38,6 +41,9 @@ interface TaskFolderCompleteEvent {
     targetFolder: string;
     commandOutput: string;
     commandError: string;
+    errorKind: string;
+    requiredBytes: number;
+    availableBytes: number;
 }
 
 interface TaskCompleteEvent {
@@ -49,6 +55,7 @@ interface DetectFolderCompleteEvent {
     targetFolder: string;
     hasChanges: boolean;
     commandError: string;
+    errorKind: string;
 }
 
 interface DetectCompleteEvent {
@@ -177,7 +184,7 @@ export const TaskQueueContextProvider = ({ children }: { children: React.ReactNo
         });
 
         if (task.type === "rclone-action" && task.action !== undefined) {
-            SyncService.ExecuteRcloneActionAsync(task.taskId, task.folders, task.action, task.dry ?? false);
+            SyncService.ExecuteRcloneActionAsync(task.taskId, task.folders, "", task.action, task.dry ?? false);
         } else if (task.type === "backup") {
             SyncService.ExecuteFullBackupAsync(task.taskId, task.dry ?? false);
         }
@@ -187,7 +194,7 @@ export const TaskQueueContextProvider = ({ children }: { children: React.ReactNo
 
     useEffect(() => {
         const unsubFolderComplete = Events.On("task-folder-complete", (event: { data: TaskFolderCompleteEvent }) => {
-            const { taskId, targetFolder, commandOutput, commandError } = event.data;
+            const { taskId, targetFolder, commandOutput, commandError, errorKind, requiredBytes, availableBytes } = event.data;
             setTasks(prev => {
                 const task = prev[taskId];
                 if (!task) return prev;
@@ -198,6 +205,9 @@ export const TaskQueueContextProvider = ({ children }: { children: React.ReactNo
                         targetFolder,
                         commandOutput,
                         commandError,
+                        errorKind,
+                        requiredBytes: requiredBytes ?? 0,
+                        availableBytes: availableBytes ?? 0,
                         completedAt: Date.now(),
                     },
                 };
@@ -362,7 +372,7 @@ export const TaskQueueContextProvider = ({ children }: { children: React.ReactNo
         setIsDetectingChanges(true);
         setDetectedChangedFolders([]);
         setCheckedFolders([]);
-        SyncService.DetectChangedFoldersAsync(taskId, folders);
+        SyncService.DetectChangedFoldersAsync(taskId, folders, "");
         return taskId;
     }, []);
 
@@ -387,7 +397,7 @@ export const TaskQueueContextProvider = ({ children }: { children: React.ReactNo
 
             // Reuse the same taskId so backend events update this task
             if (task.type === "rclone-action" && task.action) {
-                SyncService.ExecuteRcloneActionAsync(taskId, task.folders, task.action, false);
+                SyncService.ExecuteRcloneActionAsync(taskId, task.folders, "", task.action, false);
             } else if (task.type === "backup") {
                 SyncService.ExecuteFullBackupAsync(taskId, false);
             }